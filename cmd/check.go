@@ -4,14 +4,18 @@ import (
 	"errors"
 	"fmt"
 	"os"
-
-	"github.com/dlclark/regexp2"
+	"strings"
+	"time"
 
 	"github.com/antham/gommit/gommit"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
+var strict bool
+
+var verbose bool
+
 // checkCmd represents the check command
 var checkCmd = &cobra.Command{
 	Use:   "check",
@@ -47,7 +51,7 @@ func parseDirectory(path string) (string, error) {
 }
 
 func validateFileConfig() error {
-	if len(viper.GetStringMapString("matchers")) == 0 {
+	if len(viper.GetStringMapString("matchers")) == 0 && !viper.GetBool("config.conventional-commits") {
 		return errors.New("at least one matcher must be defined")
 	}
 
@@ -55,10 +59,29 @@ func validateFileConfig() error {
 		return errors.New("at least one example must be defined")
 	}
 
-	for name, matcher := range viper.GetStringMapString("matchers") {
-		_, err := regexp2.Compile(matcher, 0)
-		if err != nil {
-			return fmt.Errorf(`regexp "%s" identified by "%s" is not a valid regexp, please check the syntax`, matcher, name)
+	if _, err := buildSeverities(); err != nil {
+		return err
+	}
+
+	if viper.IsSet("config.match-timeout") {
+		if _, err := time.ParseDuration(viper.GetString("config.match-timeout")); err != nil {
+			return fmt.Errorf(`match timeout "%s" is not a valid duration, it must be written like "500ms" or "2s"`, viper.GetString("config.match-timeout"))
+		}
+	}
+
+	if viper.IsSet("config.summary-similarity") {
+		if similarity := viper.GetFloat64("config.summary-similarity"); similarity < 0 || similarity > 1 {
+			return fmt.Errorf(`summary similarity "%s" must be a number between 0 and 1`, viper.GetString("config.summary-similarity"))
+		}
+	}
+
+	if viper.GetBool("reverts.bypass-matchers") && !viper.GetBool("reverts.check") {
+		return errors.New(`reverts "bypass-matchers" requires "check" to be enabled, reverted commits must be verified`)
+	}
+
+	if viper.IsSet("config.summary-length-mode") {
+		if _, err := gommit.ParseLengthMode(viper.GetString("config.summary-length-mode")); err != nil {
+			return err
 		}
 	}
 
@@ -72,11 +95,20 @@ func processMatchResult(matchings *[]*gommit.Matching, err error, examples map[s
 		exitError()
 	}
 
+	if !verbose {
+		matchings = withoutExemptedMatchings(matchings)
+	}
+
 	if len(*matchings) != 0 {
 		renderMatchings(matchings)
-		renderExamples(examples)
+	}
 
-		exitError()
+	for _, m := range *matchings {
+		if gommit.IsFailingMatching(m, strict) {
+			renderExamples(examples)
+
+			exitError()
+		}
 	}
 
 	success("Everything is ok")
@@ -84,16 +116,114 @@ func processMatchResult(matchings *[]*gommit.Matching, err error, examples map[s
 	exitSuccess()
 }
 
+// withoutExemptedMatchings removes matchings only reporting a commit exempted from checks
+func withoutExemptedMatchings(matchings *[]*gommit.Matching) *[]*gommit.Matching {
+	filtered := []*gommit.Matching{}
+
+	for _, m := range *matchings {
+		if !gommit.IsExemptedMatching(m) {
+			filtered = append(filtered, m)
+		}
+	}
+
+	return &filtered
+}
+
+// buildExemptions reads exemptions defined by name in exemptions section
+func buildExemptions() map[string]gommit.Exemption {
+	exemptions := map[string]gommit.Exemption{}
+
+	for name := range viper.GetStringMap("exemptions") {
+		key := "exemptions." + name
+
+		exemptions[name] = gommit.Exemption{
+			AuthorName:     viper.GetString(key + ".author-name"),
+			AuthorEmail:    viper.GetString(key + ".author-email"),
+			CommitterName:  viper.GetString(key + ".committer-name"),
+			CommitterEmail: viper.GetString(key + ".committer-email"),
+			Matchers:       viper.GetStringMapString(key + ".matchers"),
+		}
+	}
+
+	return exemptions
+}
+
+// buildSeverities reads severities defined for rules, a rule is
+// identified by its ID, a deny matcher by "deny.<name>"
+func buildSeverities() (map[string]gommit.Severity, error) {
+	severities := map[string]gommit.Severity{}
+
+	for _, key := range viper.AllKeys() {
+		ID, ok := strings.CutPrefix(key, "severities.")
+		if !ok {
+			continue
+		}
+
+		severity, err := gommit.ParseSeverity(viper.GetString(key))
+		if err != nil {
+			return map[string]gommit.Severity{}, fmt.Errorf(`rule "%s" : %w`, ID, err)
+		}
+
+		severities[ID] = severity
+	}
+
+	return severities, nil
+}
+
 func buildOptions() gommit.Options {
+	severities, _ := buildSeverities()
+
 	viper.SetDefault("config.summary-length", 50)
+	viper.SetDefault("config.body-line-length", 72)
+	viper.SetDefault("config.summary-length-mode", string(gommit.LengthModeRunes))
+	viper.SetDefault("config.match-timeout", "1s")
+	viper.SetDefault("identity.placeholder-names", []string{"root", "ubuntu", "admin", "administrator", "user", "vagrant", "ec2-user", "Your Name"})
+
+	var placeholderNames []string
+
+	if viper.GetBool("identity.check-names") {
+		placeholderNames = viper.GetStringSlice("identity.placeholder-names")
+	}
 
 	return gommit.Options{
-		CheckSummaryLength:  viper.GetBool("config.check-summary-length"),
-		ExcludeMergeCommits: viper.GetBool("config.exclude-merge-commits"),
-		SummaryLength:       viper.GetInt("config.summary-length"),
+		CheckBodyLineLength:     viper.GetBool("config.check-body-line-length"),
+		CheckSignedOffBy:        viper.GetBool("trailers.signed-off-by"),
+		CheckSummaryLength:      viper.GetBool("config.check-summary-length"),
+		CheckSummarySeparator:   viper.GetBool("config.check-summary-separator"),
+		ConventionalCommits:     viper.GetBool("config.conventional-commits"),
+		ExcludeMergeCommits:     viper.GetBool("config.exclude-merge-commits"),
+		BodyLineLength:          viper.GetInt("config.body-line-length"),
+		SummaryLength:           viper.GetInt("config.summary-length"),
+		SummaryLengthMode:       gommit.LengthMode(viper.GetString("config.summary-length-mode")),
+		CheckDuplicateSummaries: viper.GetBool("config.check-duplicate-summaries"),
+		SummarySimilarity:       viper.GetFloat64("config.summary-similarity"),
+		Types:                   viper.GetStringSlice("rules.types"),
+		Scopes:                  viper.GetStringSlice("rules.scopes"),
+		RequiredTrailers:        viper.GetStringSlice("trailers.required"),
+		ForbiddenTrailers:       viper.GetStringSlice("trailers.forbidden"),
+		TrailerPatterns:         viper.GetStringMapString("trailers.patterns"),
+		DenyMatchers:            viper.GetStringMapString("deny"),
+		MergeMatchers:           viper.GetStringMapString("merge-matchers"),
+		Exemptions:              buildExemptions(),
+		IssueProjects:           viper.GetStringSlice("issues.projects"),
+		IssueExemptTypes:        viper.GetStringSlice("issues.exempt-types"),
+		CheckBranchIssueKey:     viper.GetBool("issues.check-branch"),
+		CheckReverts:            viper.GetBool("reverts.check"),
+		RevertRequireReason:     viper.GetBool("reverts.require-reason"),
+		RevertBypassMatchers:    viper.GetBool("reverts.bypass-matchers"),
+		AllowedEmailDomains:     viper.GetStringSlice("identity.allowed-domains"),
+		PlaceholderNames:        placeholderNames,
+		CheckAuthorDate:         viper.GetBool("identity.check-dates"),
+		SignatureKeyring:        viper.GetString("signatures.keyring"),
+		AllowedSignersFile:      viper.GetString("signatures.allowed-signers"),
+		Severities:              severities,
+		MatchTimeout:            viper.GetDuration("config.match-timeout"),
 	}
 }
 
 func init() {
 	RootCmd.AddCommand(checkCmd)
+
+	checkCmd.PersistentFlags().BoolVar(&strict, "strict", false, "fail on warnings")
+	checkCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "display commits exempted from checks")
 }
@@ -1,17 +1,32 @@
 package cmd
 
 import (
+	"bytes"
 	"errors"
+	"io"
+	"os"
+	"unicode/utf8"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/unicode"
 
 	"github.com/antham/gommit/gommit"
 )
 
+var cleanup string
+
+var messageFile string
+
+var stdin io.Reader = os.Stdin
+
+// utf8BOM is the byte order mark some editors write at the beginning of an UTF-8 file
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
 // checkMessageCmd represents the command that check a message
 var checkMessageCmd = &cobra.Command{
-	Use:   "message [message]",
+	Use:   "message [message] [--file path]",
 	Short: "Check message",
 	Run: func(cmd *cobra.Command, args []string) {
 		err := validateFileConfig()
@@ -21,7 +36,21 @@ var checkMessageCmd = &cobra.Command{
 			exitError()
 		}
 
-		message, err := extractCheckMessageArgs(args)
+		message, err := extractCheckMessageArgs(args, messageFile)
+		if err != nil {
+			failure(err)
+
+			exitError()
+		}
+
+		cleanupMode, err := gommit.ParseCleanupMode(cleanup)
+		if err != nil {
+			failure(err)
+
+			exitError()
+		}
+
+		path, err := parseDirectory("")
 		if err != nil {
 			failure(err)
 
@@ -29,9 +58,11 @@ var checkMessageCmd = &cobra.Command{
 		}
 
 		q := gommit.MessageQuery{
+			Path:     path,
 			Message:  message,
 			Matchers: viper.GetStringMapString("matchers"),
 			Options:  buildOptions(),
+			Cleanup:  cleanupMode,
 		}
 
 		matching, err := gommit.MatchMessageQuery(q)
@@ -46,7 +77,15 @@ var checkMessageCmd = &cobra.Command{
 	},
 }
 
-func extractCheckMessageArgs(args []string) (string, error) {
+func extractCheckMessageArgs(args []string, file string) (string, error) {
+	if file != "" {
+		if len(args) != 0 {
+			return "", errors.New("message argument can't be used with --file flag")
+		}
+
+		return readMessageFile(file)
+	}
+
 	if len(args) != 1 {
 		return "", errors.New("one argument required : message")
 	}
@@ -54,6 +93,47 @@ func extractCheckMessageArgs(args []string) (string, error) {
 	return args[0], nil
 }
 
+// readMessageFile reads a message from a file, or from stdin when file is "-"
+func readMessageFile(file string) (string, error) {
+	var data []byte
+	var err error
+
+	if file == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(file)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return decodeMessage(data)
+}
+
+// decodeMessage converts a message to UTF-8, encoding is detected from a byte
+// order mark, a message which is not valid UTF-8 is decoded as ISO-8859-1,
+// bytes of an UTF-8 message are kept untouched except for its byte order mark
+func decodeMessage(data []byte) (string, error) {
+	switch {
+	case bytes.HasPrefix(data, utf8BOM):
+		return string(data[len(utf8BOM):]), nil
+	case bytes.HasPrefix(data, []byte{0xFF, 0xFE}), bytes.HasPrefix(data, []byte{0xFE, 0xFF}):
+		decoded, err := unicode.UTF16(unicode.BigEndian, unicode.ExpectBOM).NewDecoder().Bytes(data)
+
+		return string(decoded), err
+	case utf8.Valid(data):
+		return string(data), nil
+	}
+
+	decoded, err := charmap.ISO8859_1.NewDecoder().Bytes(data)
+
+	return string(decoded), err
+}
+
 func init() {
 	checkCmd.AddCommand(checkMessageCmd)
+
+	checkMessageCmd.Flags().StringVar(&messageFile, "file", "", `read message from a file, "-" reads it from stdin`)
+	checkMessageCmd.Flags().StringVar(&cleanup, "cleanup", string(gommit.CleanupVerbatim), "clean up message like git commit.cleanup does before checking it : strip, whitespace, scissors or verbatim")
 }
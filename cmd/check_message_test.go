@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"bytes"
 	"os"
 	"os/exec"
 	"sync"
@@ -210,3 +211,375 @@ func TestCheckMessageWithBadMessage(t *testing.T) {
 	assert.Len(t, *matchings, 1, "Must return 1 commits")
 	assert.Len(t, examples, 3, "Must return 3 examples")
 }
+
+func TestCheckMessageWithConventionalCommits(t *testing.T) {
+	path, err := os.Getwd()
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	exitError = func() {
+		panic(1)
+	}
+
+	exitSuccess = func() {
+		panic(0)
+	}
+
+	var matchings *[]*gommit.Matching
+
+	renderMatchings = func(m *[]*gommit.Matching) {
+		matchings = m
+	}
+
+	renderExamples = func(e map[string]string) {}
+
+	success = func(msg string) {}
+
+	type scenario struct {
+		message  string
+		code     int
+		errCount int
+	}
+
+	scenarios := []scenario{
+		{"feat(cmd)!: everything is fine\n\nBREAKING CHANGE: nothing works as before\n", 0, 0},
+		{"feat(cmd):everything is fine\n\nbreaking change: nothing works as before\n", 1, 2},
+	}
+
+	for _, s := range scenarios {
+		var code int
+		var w sync.WaitGroup
+
+		matchings = nil
+
+		w.Add(1)
+
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					code = r.(int)
+				}
+
+				w.Done()
+			}()
+
+			os.Args = []string{"", "--config", path + "/../features/.gommit-conventional-commits.toml", "check", "message", s.message}
+
+			Execute()
+		}()
+
+		w.Wait()
+
+		assert.EqualValues(t, s.code, code)
+
+		if s.errCount > 0 {
+			assert.Len(t, *matchings, 1)
+			assert.Len(t, (*matchings)[0].RuleViolations(gommit.RuleConventionalCommits), s.errCount)
+		}
+	}
+}
+
+func TestCheckMessageWithWarnings(t *testing.T) {
+	path, err := os.Getwd()
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	exitError = func() {
+		panic(1)
+	}
+
+	exitSuccess = func() {
+		panic(0)
+	}
+
+	var matchings *[]*gommit.Matching
+
+	renderMatchings = func(m *[]*gommit.Matching) {
+		matchings = m
+	}
+
+	renderExamples = func(e map[string]string) {}
+
+	success = func(msg string) {}
+
+	type scenario struct {
+		arguments []string
+		code      int
+	}
+
+	scenarios := []scenario{
+		{[]string{"check", "message", "feat(cmd) : a summary longer than 20 characters\n"}, 0},
+		{[]string{"check", "--strict", "message", "feat(cmd) : a summary longer than 20 characters\n"}, 1},
+	}
+
+	for _, s := range scenarios {
+		var code int
+		var w sync.WaitGroup
+
+		w.Add(1)
+
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					code = r.(int)
+				}
+
+				w.Done()
+			}()
+
+			os.Args = append([]string{"", "--config", path + "/../features/.gommit-severities.toml"}, s.arguments...)
+
+			Execute()
+		}()
+
+		w.Wait()
+
+		assert.EqualValues(t, s.code, code)
+		assert.Len(t, *matchings, 1)
+		assert.Equal(t, gommit.SeverityWarning, (*matchings)[0].RuleViolations(gommit.RuleSummaryLength)[0].Severity)
+	}
+
+	strict = false
+}
+
+func TestCheckMessageWithSuppressions(t *testing.T) {
+	path, err := os.Getwd()
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	exitError = func() {
+		panic(1)
+	}
+
+	exitSuccess = func() {
+		panic(0)
+	}
+
+	var matchings *[]*gommit.Matching
+
+	renderMatchings = func(m *[]*gommit.Matching) {
+		matchings = m
+	}
+
+	renderExamples = func(e map[string]string) {}
+
+	success = func(msg string) {}
+
+	type scenario struct {
+		message    string
+		code       int
+		violations int
+		suppressed int
+	}
+
+	scenarios := []scenario{
+		{"feat(cmd) : a summary longer than 20 characters\n\nGommit-Skip: summary-length (imported history)\n", 0, 0, 1},
+		{"feat(cmd) : a summary longer than 20 characters\n\nGommit-Skip: summary-length\n", 1, 2, 0},
+	}
+
+	for _, s := range scenarios {
+		var code int
+		var w sync.WaitGroup
+
+		w.Add(1)
+
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					code = r.(int)
+				}
+
+				w.Done()
+			}()
+
+			os.Args = []string{"", "--config", path + "/../features/.gommit-severities.toml", "check", "--strict", "message", s.message}
+
+			Execute()
+		}()
+
+		w.Wait()
+
+		assert.EqualValues(t, s.code, code)
+		assert.Len(t, *matchings, 1)
+		assert.Len(t, (*matchings)[0].Violations, s.violations)
+		assert.Len(t, (*matchings)[0].Suppressed, s.suppressed)
+	}
+
+	strict = false
+}
+
+func TestCheckMessageWithCleanup(t *testing.T) {
+	path, err := os.Getwd()
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	exitError = func() {
+		panic(1)
+	}
+
+	exitSuccess = func() {
+		panic(0)
+	}
+
+	var errc error
+
+	failure = func(err error) {
+		errc = err
+	}
+
+	renderMatchings = func(m *[]*gommit.Matching) {}
+
+	renderExamples = func(e map[string]string) {}
+
+	success = func(msg string) {}
+
+	type scenario struct {
+		cleanup string
+		code    int
+	}
+
+	scenarios := []scenario{
+		{"verbatim", 1},
+		{"strip", 0},
+		{"whatever", 1},
+	}
+
+	for _, s := range scenarios {
+		var code int
+		var w sync.WaitGroup
+
+		w.Add(1)
+
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					code = r.(int)
+				}
+
+				w.Done()
+			}()
+
+			os.Args = []string{"", "--config", path + "/../features/.gommit-severities.toml", "check", "--strict", "message", "--cleanup", s.cleanup, "# Please enter the commit message for your changes\nfeat(cmd) : a fix\n"}
+
+			Execute()
+		}()
+
+		w.Wait()
+
+		assert.EqualValues(t, s.code, code)
+	}
+
+	assert.EqualError(t, errc, `cleanup mode "whatever" doesn't exist, it must be strip, whitespace, scissors or verbatim`)
+
+	strict = false
+	cleanup = string(gommit.CleanupVerbatim)
+}
+
+func TestExtractCheckMessageArgs(t *testing.T) {
+	file := t.TempDir() + "/COMMIT_EDITMSG"
+	assert.NoError(t, os.WriteFile(file, []byte("feat(cmd) : a feature\n\nbody\n\n"), 0o600))
+
+	message, err := extractCheckMessageArgs([]string{}, file)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "feat(cmd) : a feature\n\nbody\n\n", message, "Must preserve trailing newlines")
+
+	stdin = bytes.NewBufferString("feat(cmd) : from stdin\n")
+
+	message, err = extractCheckMessageArgs([]string{}, "-")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "feat(cmd) : from stdin\n", message)
+
+	stdin = os.Stdin
+
+	_, err = extractCheckMessageArgs([]string{"a message"}, file)
+
+	assert.EqualError(t, err, "message argument can't be used with --file flag")
+
+	_, err = extractCheckMessageArgs([]string{}, file+"-missing")
+
+	assert.Error(t, err)
+
+	message, err = extractCheckMessageArgs([]string{"a message"}, "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "a message", message)
+}
+
+func TestDecodeMessage(t *testing.T) {
+	type scenario struct {
+		name string
+		data []byte
+	}
+
+	scenarios := []scenario{
+		{"UTF-8", []byte("fix(api) : gère les réponses\r\n")},
+		{"UTF-8 with BOM", append([]byte{0xEF, 0xBB, 0xBF}, []byte("fix(api) : gère les réponses\r\n")...)},
+		{"UTF-16 little endian", []byte{0xFF, 0xFE, 'f', 0, 'i', 0, 'x', 0, '(', 0, 'a', 0, 'p', 0, 'i', 0, ')', 0, ' ', 0, ':', 0, ' ', 0, 'g', 0, 0xE8, 0, 'r', 0, 'e', 0, ' ', 0, 'l', 0, 'e', 0, 's', 0, ' ', 0, 'r', 0, 0xE9, 0, 'p', 0, 'o', 0, 'n', 0, 's', 0, 'e', 0, 's', 0, '\r', 0, '\n', 0}},
+		{"UTF-16 big endian", []byte{0xFE, 0xFF, 0, 'f', 0, 'i', 0, 'x', 0, '(', 0, 'a', 0, 'p', 0, 'i', 0, ')', 0, ' ', 0, ':', 0, ' ', 0, 'g', 0, 0xE8, 0, 'r', 0, 'e', 0, ' ', 0, 'l', 0, 'e', 0, 's', 0, ' ', 0, 'r', 0, 0xE9, 0, 'p', 0, 'o', 0, 'n', 0, 's', 0, 'e', 0, 's', 0, '\r', 0, '\n'}},
+		{"ISO-8859-1", []byte("fix(api) : g\xe8re les r\xe9ponses\r\n")},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			message, err := decodeMessage(s.data)
+
+			assert.NoError(t, err)
+			assert.Equal(t, "fix(api) : gère les réponses\r\n", message)
+		})
+	}
+}
+
+func TestCheckMessageWithFile(t *testing.T) {
+	path, err := os.Getwd()
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	file := t.TempDir() + "/COMMIT_EDITMSG"
+	assert.NoError(t, os.WriteFile(file, []byte("feat(cmd) : everything is fine\n"), 0o600))
+
+	var code int
+	var message string
+	var w sync.WaitGroup
+
+	success = func(msg string) {
+		message = msg
+	}
+
+	exitError = func() {
+		panic(1)
+	}
+
+	exitSuccess = func() {
+		panic(0)
+	}
+
+	w.Add(1)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				code = r.(int)
+			}
+
+			w.Done()
+		}()
+
+		os.Args = []string{"", "--config", path + "/../features/.gommit.toml", "check", "message", "--file", file}
+
+		Execute()
+	}()
+
+	w.Wait()
+
+	assert.EqualValues(t, 0, code, "Must exit without errors (exit 0)")
+	assert.EqualValues(t, "Everything is ok", message, "Must return a message to inform everything is ok")
+
+	messageFile = ""
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"runtime"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -10,6 +11,8 @@ import (
 	"github.com/antham/gommit/gommit"
 )
 
+var jobs int
+
 // checkRangeCmd represents the check command
 var checkRangeCmd = &cobra.Command{
 	Use:   "range [revisionfrom] [revisionTo] [&path]",
@@ -35,6 +38,7 @@ var checkRangeCmd = &cobra.Command{
 			To:       to,
 			Matchers: viper.GetStringMapString("matchers"),
 			Options:  buildOptions(),
+			Jobs:     jobs,
 		}
 
 		matchings, err := gommit.MatchRangeQuery(q)
@@ -69,4 +73,6 @@ func extractCheckRangeArgs(args []string) (string, string, string, error) {
 
 func init() {
 	checkCmd.AddCommand(checkRangeCmd)
+
+	checkRangeCmd.Flags().IntVar(&jobs, "jobs", runtime.NumCPU(), "number of commits analyzed concurrently")
 }
@@ -4,10 +4,12 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"runtime"
 	"sync"
 	"testing"
 
 	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/antham/gommit/gommit"
@@ -251,3 +253,121 @@ func TestCheckRangeWithNoErrors(t *testing.T) {
 	assert.EqualValues(t, 0, code, "Must exit without errors (exit 0)")
 	assert.EqualValues(t, "Everything is ok", message, "Must return a message to inform everything is ok")
 }
+
+func TestCheckRangeWithJobs(t *testing.T) {
+	path, err := os.Getwd()
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	err = exec.Command("../features/repo.sh").Run()
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	exitError = func() {
+		panic(1)
+	}
+
+	exitSuccess = func() {
+		panic(0)
+	}
+
+	success = func(msg string) {}
+
+	IDs := map[string][]string{}
+
+	for _, j := range []string{"1", "4"} {
+		var w sync.WaitGroup
+
+		renderMatchings = func(m *[]*gommit.Matching) {
+			for _, matching := range *m {
+				IDs[j] = append(IDs[j], matching.Context["ID"])
+			}
+		}
+
+		w.Add(1)
+
+		go func() {
+			defer func() {
+				recover()
+
+				w.Done()
+			}()
+
+			os.Args = []string{"", "--config", path + "/../features/.gommit-severities.toml", "check", "range", "--jobs", j, "test~4", "test", path + "/testing-repository"}
+
+			Execute()
+		}()
+
+		w.Wait()
+	}
+
+	assert.Len(t, IDs["1"], 9)
+	assert.Equal(t, IDs["1"], IDs["4"], "Must keep commits order")
+
+	jobs = runtime.NumCPU()
+	viper.Reset()
+}
+
+func TestCheckRangeWithExemptions(t *testing.T) {
+	path, err := os.Getwd()
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	for _, filename := range []string{"../features/repo.sh", "../features/bot-commits.sh"} {
+		err := exec.Command(filename).Run()
+		if err != nil {
+			logrus.Fatal(err)
+		}
+	}
+
+	exitError = func() {
+		panic(1)
+	}
+
+	exitSuccess = func() {
+		panic(0)
+	}
+
+	success = func(msg string) {}
+
+	renderExamples = func(examples map[string]string) {}
+
+	exemptions := map[string][]string{}
+
+	for _, flag := range []string{"--verbose=false", "--verbose"} {
+		var w sync.WaitGroup
+
+		exemptions[flag] = []string{}
+
+		renderMatchings = func(m *[]*gommit.Matching) {
+			for _, matching := range *m {
+				exemptions[flag] = append(exemptions[flag], matching.Exemption)
+			}
+		}
+
+		w.Add(1)
+
+		go func() {
+			defer func() {
+				recover()
+
+				w.Done()
+			}()
+
+			os.Args = []string{"", "--config", path + "/../features/.gommit-exemptions.toml", "check", "range", flag, "test~3", "test", path + "/testing-repository"}
+
+			Execute()
+		}()
+
+		w.Wait()
+	}
+
+	assert.Equal(t, []string{""}, exemptions["--verbose=false"], "Must hide exempted commits")
+	assert.Equal(t, []string{"", "renovate", "dependabot"}, exemptions["--verbose"], "Must display exempted commits in verbose mode")
+
+	verbose = false
+	viper.Reset()
+}
@@ -2,8 +2,11 @@ package cmd
 
 import (
 	"os"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/antham/gommit/gommit"
@@ -12,7 +15,95 @@ import (
 func TestBuildOptionsWithDefaultValues(t *testing.T) {
 	opts := buildOptions()
 
-	assert.Equal(t, gommit.Options{SummaryLength: 50, CheckSummaryLength: false, ExcludeMergeCommits: false}, opts)
+	assert.Equal(t, gommit.Options{SummaryLength: 50, SummaryLengthMode: gommit.LengthModeRunes, BodyLineLength: 72, CheckSummaryLength: false, ExcludeMergeCommits: false, TrailerPatterns: map[string]string{}, DenyMatchers: map[string]string{}, MergeMatchers: map[string]string{}, Exemptions: map[string]gommit.Exemption{}, Severities: map[string]gommit.Severity{}, MatchTimeout: time.Second}, opts)
+}
+
+func TestBuildOptionsWithDuplicateSummaries(t *testing.T) {
+	viper.Reset()
+	viper.Set("config.check-duplicate-summaries", true)
+	viper.Set("config.summary-similarity", 0.9)
+
+	opts := buildOptions()
+
+	assert.True(t, opts.CheckDuplicateSummaries)
+	assert.Equal(t, 0.9, opts.SummarySimilarity)
+
+	viper.Reset()
+}
+
+func TestBuildOptionsWithReverts(t *testing.T) {
+	viper.Reset()
+	viper.Set("reverts.check", true)
+	viper.Set("reverts.require-reason", true)
+	viper.Set("reverts.bypass-matchers", true)
+
+	opts := buildOptions()
+
+	assert.True(t, opts.CheckReverts)
+	assert.True(t, opts.RevertRequireReason)
+	assert.True(t, opts.RevertBypassMatchers)
+
+	viper.Reset()
+}
+
+func TestBuildOptionsWithIdentityChecks(t *testing.T) {
+	viper.Reset()
+	viper.Set("identity.allowed-domains", []string{"example.com"})
+	viper.Set("identity.check-dates", true)
+
+	opts := buildOptions()
+
+	assert.Equal(t, []string{"example.com"}, opts.AllowedEmailDomains)
+	assert.Nil(t, opts.PlaceholderNames, "Must not check names unless it's enabled")
+	assert.True(t, opts.CheckAuthorDate)
+
+	viper.Set("identity.check-names", true)
+
+	assert.Equal(t, []string{"root", "ubuntu", "admin", "administrator", "user", "vagrant", "ec2-user", "Your Name"}, buildOptions().PlaceholderNames, "Must use default placeholders")
+
+	viper.Set("identity.placeholder-names", []string{"jenkins"})
+
+	assert.Equal(t, []string{"jenkins"}, buildOptions().PlaceholderNames)
+
+	viper.Reset()
+}
+
+func TestBuildOptionsWithSignatures(t *testing.T) {
+	viper.Reset()
+	viper.Set("signatures.keyring", ".gommit/keyring.asc")
+	viper.Set("signatures.allowed-signers", ".gommit/allowed_signers")
+
+	opts := buildOptions()
+
+	assert.Equal(t, ".gommit/keyring.asc", opts.SignatureKeyring)
+	assert.Equal(t, ".gommit/allowed_signers", opts.AllowedSignersFile)
+
+	viper.Reset()
+}
+
+func TestBuildOptionsWithExemptions(t *testing.T) {
+	viper.Reset()
+	viper.SetConfigFile("../features/.gommit-exemptions.toml")
+	assert.NoError(t, viper.ReadInConfig())
+
+	opts := buildOptions()
+
+	assert.Equal(t, map[string]gommit.Exemption{
+		"dependabot": {AuthorName: `^dependabot\[bot\]$`, Matchers: map[string]string{"bump": "^Bump \\S+ from \\S+ to \\S+\n"}},
+		"renovate":   {AuthorEmail: `\+renovate\[bot\]@users\.noreply\.github\.com$`, Matchers: map[string]string{}},
+	}, opts.Exemptions)
+
+	viper.Reset()
+}
+
+func TestWithoutExemptedMatchings(t *testing.T) {
+	matchings := &[]*gommit.Matching{
+		{Context: map[string]string{"ID": "1"}, Exemption: "renovate"},
+		{Context: map[string]string{"ID": "2"}, Exemption: "dependabot", Violations: []gommit.Violation{{Message: "no template match commit message"}}},
+		{Context: map[string]string{"ID": "3"}, Violations: []gommit.Violation{{Message: "no template match commit message"}}},
+	}
+
+	assert.Equal(t, &[]*gommit.Matching{(*matchings)[1], (*matchings)[2]}, withoutExemptedMatchings(matchings))
 }
 
 func TestParseDirectoryWithErrors(t *testing.T) {
@@ -28,3 +119,81 @@ func TestParseDirectoryWithErrors(t *testing.T) {
 
 	assert.EqualError(t, err, `"/tmp/file" must be a directory`)
 }
+
+func TestBuildSeverities(t *testing.T) {
+	viper.Reset()
+	viper.SetConfigType("toml")
+
+	err := viper.ReadConfig(strings.NewReader("[severities]\nbody-line-length=\"warning\"\ndeny=\"warning\"\n\"deny.wip\"=\"info\"\n"))
+	assert.NoError(t, err)
+
+	severities, err := buildSeverities()
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]gommit.Severity{
+		gommit.RuleBodyLineLength: gommit.SeverityWarning,
+		gommit.RuleDeny:           gommit.SeverityWarning,
+		"deny.wip":                gommit.SeverityInfo,
+	}, severities, "Must read a rule and one of its matchers severities")
+
+	viper.Reset()
+}
+
+func TestValidateFileConfigWithAnInvalidSeverity(t *testing.T) {
+	viper.Reset()
+	viper.Set("matchers", map[string]string{"simple": ".*"})
+	viper.Set("examples", map[string]string{"simple": "test"})
+	viper.Set("severities.summary-length", "whatever")
+
+	assert.EqualError(t, validateFileConfig(), `rule "summary-length" : severity "whatever" doesn't exist, it must be error, warning or info`)
+
+	viper.Reset()
+}
+
+func TestValidateFileConfigWithAnInvalidSummaryLengthMode(t *testing.T) {
+	viper.Reset()
+	viper.Set("matchers", map[string]string{"simple": ".*"})
+	viper.Set("examples", map[string]string{"simple": "test"})
+	viper.Set("config.summary-length-mode", "bytes")
+
+	assert.EqualError(t, validateFileConfig(), `length mode "bytes" doesn't exist, it must be runes or display-width`)
+
+	viper.Reset()
+}
+
+func TestValidateFileConfigWithAnInvalidMatchTimeout(t *testing.T) {
+	viper.Reset()
+	viper.Set("matchers", map[string]string{"simple": ".*"})
+	viper.Set("examples", map[string]string{"simple": "test"})
+	viper.Set("config.match-timeout", "1 second")
+
+	assert.EqualError(t, validateFileConfig(), `match timeout "1 second" is not a valid duration, it must be written like "500ms" or "2s"`)
+
+	viper.Reset()
+}
+
+func TestValidateFileConfigWithAnInvalidSummarySimilarity(t *testing.T) {
+	viper.Reset()
+	viper.Set("matchers", map[string]string{"simple": ".*"})
+	viper.Set("examples", map[string]string{"simple": "test"})
+	viper.Set("config.summary-similarity", 1.5)
+
+	assert.EqualError(t, validateFileConfig(), `summary similarity "1.5" must be a number between 0 and 1`)
+
+	viper.Reset()
+}
+
+func TestValidateFileConfigWithRevertsBypassingMatchersWithoutCheck(t *testing.T) {
+	viper.Reset()
+	viper.Set("matchers", map[string]string{"simple": ".*"})
+	viper.Set("examples", map[string]string{"simple": "test"})
+	viper.Set("reverts.bypass-matchers", true)
+
+	assert.EqualError(t, validateFileConfig(), `reverts "bypass-matchers" requires "check" to be enabled, reverted commits must be verified`)
+
+	viper.Set("reverts.check", true)
+
+	assert.NoError(t, validateFileConfig())
+
+	viper.Reset()
+}
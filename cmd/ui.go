@@ -29,42 +29,74 @@ var renderMatchings = func(matchings *[]*gommit.Matching) {
 		fmt.Println()
 
 		if ID, ok := m.Context["ID"]; ok {
-			fmt.Printf("%s%s\n", color.YellowString("Id       : "), color.WhiteString("%s", ID))
+			fmt.Printf("%s%s\n", color.YellowString("Id           : "), color.WhiteString("%s", ID))
 		}
 
 		if message, ok := m.Context["message"]; ok {
-			color.Yellow("Message  : ")
+			color.Yellow("Message      : ")
 
 			for _, field := range strings.Split(message, "\n") {
-				fmt.Printf("%s%s\n", color.YellowString("           "), color.WhiteString("%s", field))
+				fmt.Printf("%s%s\n", color.YellowString("               "), color.WhiteString("%s", field))
 			}
 		}
 
 		fmt.Println()
 
-		errs := []error{}
-
-		if m.MessageError != nil {
-			errs = append(errs, m.MessageError)
+		if m.Exemption != "" {
+			fmt.Printf("%s%s\n", color.YellowString("Exempted     : "), color.WhiteString(`by "%s" exemption`, m.Exemption))
 		}
 
-		if m.SummaryError != nil {
-			errs = append(errs, m.SummaryError)
+		for i, v := range m.Violations {
+			if i == 0 {
+				fmt.Printf("%s", color.YellowString("Violation(s) : "))
+			} else {
+				fmt.Printf("               ")
+			}
+
+			fmt.Printf("- %s\n", renderViolation(v))
 		}
 
-		for i, e := range errs {
+		for i, v := range m.Suppressed {
 			if i == 0 {
-				fmt.Printf("%s", color.YellowString("Error(s) : "))
-				fmt.Printf("- %s\n", color.RedString("%s", e.Error()))
+				fmt.Printf("%s", color.YellowString("Skipped      : "))
 			} else {
-				fmt.Printf("           - %s\n", color.RedString("%s", e.Error()))
+				fmt.Printf("               ")
 			}
+
+			fmt.Printf("- %s\n", renderSuppressedViolation(v))
 		}
 
 		fmt.Println()
 	}
 }
 
+func renderViolation(violation gommit.Violation) string {
+	message := fmt.Sprintf("[%s] %s", violation.RuleID, violation.Message)
+
+	if !violation.Span.IsZero() {
+		message += fmt.Sprintf(" (%s)", violation.Span)
+	}
+
+	switch violation.Severity {
+	case gommit.SeverityWarning:
+		return color.YellowString("[warning] %s", message)
+	case gommit.SeverityInfo:
+		return color.CyanString("[info] %s", message)
+	default:
+		return color.RedString("%s", message)
+	}
+}
+
+func renderSuppressedViolation(violation gommit.Violation) string {
+	message := fmt.Sprintf("[%s] %s", violation.RuleID, violation.Message)
+
+	if !violation.Span.IsZero() {
+		message += fmt.Sprintf(" (%s)", violation.Span)
+	}
+
+	return color.WhiteString("%s, reason : %s", message, violation.SuppressionReason)
+}
+
 var renderExamples = func(examples map[string]string) {
 	color.White("=======")
 	fmt.Println()
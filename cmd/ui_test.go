@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/antham/gommit/gommit"
+)
+
+func TestRenderViolation(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = true
+
+	defer func() { color.NoColor = noColor }()
+
+	type scenario struct {
+		violation gommit.Violation
+		expected  string
+	}
+
+	scenarios := []scenario{
+		{gommit.Violation{RuleID: gommit.RuleTemplate, Message: "no template match commit message"}, "[template] no template match commit message"},
+		{gommit.Violation{RuleID: gommit.RuleSummaryLength, Severity: gommit.SeverityWarning, Message: "commit summary is too long", Span: gommit.Span{Start: gommit.Position{Line: 1, Column: 51}, End: gommit.Position{Line: 1, Column: 60}}}, "[warning] [summary-length] commit summary is too long (line 1, column 51-60)"},
+		{gommit.Violation{RuleID: "deny.wip", Severity: gommit.SeverityInfo, Message: `deny rule "wip" matched "WIP"`}, `[info] [deny.wip] deny rule "wip" matched "WIP"`},
+	}
+
+	for _, s := range scenarios {
+		assert.Equal(t, s.expected, renderViolation(s.violation))
+	}
+}
@@ -0,0 +1,13 @@
+#!/bin/bash
+
+cd testing-repository || exit 1
+
+# Fix file 8 commit with a fixup commit
+echo "fix" > file8
+git add file8
+git commit --quiet --fixup HEAD
+
+# Add a squash commit targeting a commit which is not part of the history
+touch file9
+git add file9
+git commit --quiet -m "squash! feat(file9) : new file 9"
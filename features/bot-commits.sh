@@ -0,0 +1,22 @@
+#!/bin/bash
+
+cd testing-repository || exit 1
+
+# Add a commit made by dependabot
+touch file9
+git add file9
+GIT_AUTHOR_NAME="dependabot[bot]" GIT_AUTHOR_EMAIL="49699333+dependabot[bot]@users.noreply.github.com" git commit --quiet -F- <<EOF2
+Bump lodash from 4.17.20 to 4.17.21
+
+Bumps lodash from 4.17.20 to 4.17.21.
+EOF2
+
+# Add a commit made by renovate
+touch file10
+git add file10
+GIT_AUTHOR_NAME="renovate[bot]" GIT_AUTHOR_EMAIL="29139614+renovate[bot]@users.noreply.github.com" git commit --quiet -m "Update dependency lodash to v5"
+
+# Add a commit made by a human pretending to be dependabot
+touch file11
+git add file11
+git commit --quiet -m "Bump everything"
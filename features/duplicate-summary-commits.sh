@@ -0,0 +1,26 @@
+#!/bin/bash
+
+cd testing-repository || exit 1
+
+# Add commits sharing the same summary
+touch file9
+git add file9
+git commit --quiet -m "feat(file) : fix tests"
+
+touch file10
+git add file10
+git commit --quiet -m "feat(file) : Fix tests."
+
+# Add a commit whose summary is close to the previous ones
+touch file11
+git add file11
+git commit --quiet -m "feat(file) : fix test"
+
+# Add a duplicate waived with a Gommit-Skip trailer
+touch file12
+git add file12
+git commit --quiet -F- <<EOF2
+feat(file) : fix tests
+
+Gommit-Skip: duplicate-summary (tests are fixed in several steps)
+EOF2
@@ -0,0 +1,18 @@
+#!/bin/bash
+
+cd testing-repository || exit 1
+
+# Add a commit made by a misconfigured user
+touch file9
+git add file9
+GIT_AUTHOR_NAME="root" GIT_AUTHOR_EMAIL="root@localhost" git commit --quiet -m "feat(file9) : new file 9"
+
+# Add a commit authored in the future
+touch file10
+git add file10
+GIT_AUTHOR_DATE="2099-01-01T00:00:00Z" git commit --quiet -m "feat(file10) : new file 10"
+
+# Add a commit authored before the first commit
+touch file11
+git add file11
+GIT_AUTHOR_DATE="2000-01-01T00:00:00Z" git commit --quiet -m "feat(file11) : new file 11"
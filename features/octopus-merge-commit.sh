@@ -0,0 +1,30 @@
+#!/bin/bash
+
+cd testing-repository || exit 1
+
+# Create branch octopus1
+git checkout --quiet -b octopus1
+
+touch file9
+git add file9
+git commit --quiet -F- <<EOF2
+feat(file9) : new file 9
+
+create a new file 9
+EOF2
+
+# Create branch octopus2 from test
+git checkout --quiet test
+git checkout --quiet -b octopus2
+
+touch file10
+git add file10
+git commit --quiet -F- <<EOF2
+feat(file10) : new file 10
+
+create a new file 10
+EOF2
+
+# Merge both branches at once in branch test
+git checkout --quiet test
+git merge --quiet --no-edit --no-ff octopus1 octopus2
@@ -0,0 +1,17 @@
+#!/bin/bash
+
+cd testing-repository || exit 1
+
+# Revert file 8 commit
+git revert --quiet --no-edit HEAD
+
+# Add a revert commit referencing a commit which is not part of the repository
+touch file9
+git add file9
+git commit --quiet -F- <<EOF2
+Revert "feat(file9) : new file 9"
+
+This reverts commit 0123456789abcdef0123456789abcdef01234567.
+
+File 9 broke the build.
+EOF2
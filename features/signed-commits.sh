@@ -0,0 +1,47 @@
+#!/bin/bash
+
+cd testing-repository || exit 1
+
+keys=.git/keys
+mkdir -p $keys/gnupg
+chmod 700 $keys/gnupg
+export GNUPGHOME=$keys/gnupg
+
+# Generate a trusted and an untrusted key for gpg and ssh
+gpg --batch --quiet --passphrase '' --quick-gen-key "Trusted <trusted@example.com>" ed25519 sign never 2> /dev/null
+gpg --batch --quiet --passphrase '' --quick-gen-key "Stranger <stranger@example.com>" ed25519 sign never 2> /dev/null
+gpg --batch --quiet --armor --export trusted@example.com > $keys/keyring.asc
+
+ssh-keygen -q -t ed25519 -N '' -C trusted -f $keys/trusted
+ssh-keygen -q -t ed25519 -N '' -C stranger -f $keys/stranger
+echo "trusted@example.com namespaces=\"git\" $(cat $keys/trusted.pub)" > $keys/allowed_signers
+
+# Add an unsigned commit
+touch file9
+git add file9
+git commit --quiet -m "feat(file9) : new file 9"
+
+# Add commits signed with gpg keys
+touch file10
+git add file10
+git commit --quiet -S"trusted@example.com" -m "feat(file10) : new file 10"
+
+touch file11
+git add file11
+git commit --quiet -S"stranger@example.com" -m "feat(file11) : new file 11"
+
+# Add commits signed with ssh keys
+touch file12
+git add file12
+git -c gpg.format=ssh commit --quiet -S"$keys/trusted" -m "feat(file12) : new file 12"
+
+touch file13
+git add file13
+git -c gpg.format=ssh commit --quiet -S"$keys/stranger" -m "feat(file13) : new file 13"
+
+# Add a commit whose message was altered after being signed
+touch file14
+git add file14
+git commit --quiet -S"trusted@example.com" -m "feat(file14) : new file 14"
+git reset --quiet --soft "$(git cat-file commit HEAD | sed 's/new file 14/new file 15/' | git hash-object -t commit -w --stdin)"
+gpgconf --kill gpg-agent
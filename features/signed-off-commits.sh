@@ -0,0 +1,17 @@
+#!/bin/bash
+
+cd testing-repository || exit 1
+
+# Add file 10 with a commit signed off by its author
+touch file10
+git add file10
+git commit --quiet --signoff -m "feat(file10) : new file 10"
+
+# Add file 11 with a commit signed off by someone else
+touch file11
+git add file11
+git commit --quiet -F- <<EOF2
+feat(file11) : new file 11
+
+Signed-off-by: John Doe <john@example.com>
+EOF2
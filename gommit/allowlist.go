@@ -0,0 +1,72 @@
+package gommit
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// maxSuggestionDistance is the maximum edit distance between a word and
+// an allowed value to suggest this value as a replacement
+const maxSuggestionDistance = 2
+
+// checkAllowedValue returns a violation if value is not part of allowed values,
+// the violation suggests the closest allowed value when one is close enough
+func checkAllowedValue(kind string, value string, allowed []string) *Violation {
+	if len(allowed) == 0 || value == "" || slices.Contains(allowed, value) {
+		return nil
+	}
+
+	if suggestion, ok := closestWord(value, allowed); ok {
+		return &Violation{Message: fmt.Sprintf(`unknown %s "%s", did you mean "%s"?`, kind, value, suggestion), Suggestion: suggestion}
+	}
+
+	return &Violation{Message: fmt.Sprintf(`unknown %s "%s", expected one of %s`, kind, value, strings.Join(allowed, ", "))}
+}
+
+// closestWord returns the candidate with the smallest edit distance from word
+// if this distance is not greater than maxSuggestionDistance
+func closestWord(word string, candidates []string) (string, bool) {
+	best := ""
+	bestDistance := maxSuggestionDistance + 1
+
+	for _, candidate := range candidates {
+		if d := levenshteinDistance(strings.ToLower(word), strings.ToLower(candidate)); d < bestDistance {
+			best = candidate
+			bestDistance = d
+		}
+	}
+
+	return best, bestDistance <= maxSuggestionDistance
+}
+
+// levenshteinDistance computes the minimum number of single character edits
+// required to change a string into another
+func levenshteinDistance(a string, b string) int {
+	ra := []rune(a)
+	rb := []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(rb)]
+}
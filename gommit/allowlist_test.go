@@ -0,0 +1,26 @@
+package gommit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckAllowedValue(t *testing.T) {
+	allowed := []string{"backend", "frontend", "ci"}
+
+	assert.Nil(t, checkAllowedValue("scope", "frontend", allowed))
+	assert.Nil(t, checkAllowedValue("scope", "", allowed), "Must accept an empty value")
+	assert.Nil(t, checkAllowedValue("scope", "whatever", []string{}), "Must accept any value when no allowed values are defined")
+	assert.Equal(t, &Violation{Message: `unknown scope "frontned", did you mean "frontend"?`, Suggestion: "frontend"}, checkAllowedValue("scope", "frontned", allowed))
+	assert.Equal(t, &Violation{Message: `unknown scope "database", expected one of backend, frontend, ci`}, checkAllowedValue("scope", "database", allowed))
+}
+
+func TestLevenshteinDistance(t *testing.T) {
+	assert.Equal(t, 0, levenshteinDistance("feat", "feat"))
+	assert.Equal(t, 1, levenshteinDistance("fet", "feat"))
+	assert.Equal(t, 2, levenshteinDistance("frontned", "frontend"))
+	assert.Equal(t, 3, levenshteinDistance("kitten", "sitting"))
+	assert.Equal(t, 4, levenshteinDistance("", "test"))
+	assert.Equal(t, 1, levenshteinDistance("café", "cafe"))
+}
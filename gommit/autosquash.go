@@ -0,0 +1,119 @@
+package gommit
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// autosquashPrefixes are prefixes git commit --fixup and --squash add to the summary of
+// the commit they target, they are squashed by git rebase --autosquash
+var autosquashPrefixes = []string{"fixup! ", "squash! ", "amend! "}
+
+// hashPrefixRegexp matches an abbreviated commit hash git accepts as an autosquash target
+var hashPrefixRegexp = regexp.MustCompile(`^[0-9a-f]{4,40}$`)
+
+// splitAutosquashSummary splits a summary like "fixup! fixup! feat : a feature" into its
+// autosquash prefixes and the summary of the commit it targets, prefixes are empty if
+// summary doesn't belong to an autosquash commit
+func splitAutosquashSummary(summary string) (string, string) {
+	target := summary
+
+	for {
+		found := false
+
+		for _, prefix := range autosquashPrefixes {
+			if t, ok := strings.CutPrefix(target, prefix); ok {
+				target = t
+				found = true
+			}
+		}
+
+		if !found {
+			return summary[:len(summary)-len(target)], target
+		}
+	}
+}
+
+// autosquashKind returns the first autosquash prefix like "fixup!"
+func autosquashKind(prefixes string) string {
+	kind, _, _ := strings.Cut(prefixes, " ")
+
+	return kind
+}
+
+// isAutosquashTarget returns true if commit is targeted by an autosquash commit,
+// target is matched like git does against the summary or the hash of the commit
+func isAutosquashTarget(target string, commit *object.Commit) bool {
+	summary := ParseMessage(commit.Message).Summary
+
+	if summary == target || strings.HasPrefix(summary, target) {
+		return true
+	}
+
+	return hashPrefixRegexp.MatchString(target) && strings.HasPrefix(commit.Hash.String(), target)
+}
+
+// autosquashRule rejects autosquash commits of a range, their messages are checked
+// as if their summary was the one of the commit they target
+type autosquashRule struct{}
+
+func (r autosquashRule) ID() string {
+	return RuleAutosquash
+}
+
+func (r autosquashRule) Check(message *Message, commit *object.Commit) []Violation {
+	return nil
+}
+
+func (r autosquashRule) CheckRange(commits []*object.Commit) map[int][]Violation {
+	return checkAutosquashCommits(commits)
+}
+
+// checkAutosquashCommits rejects autosquash commits of a range and ensures the commit they
+// target is part of the range, commits are sorted from the newest to the oldest so a target
+// must be found after an autosquash commit, violations are indexed by commit position
+func checkAutosquashCommits(commits []*object.Commit) map[int][]Violation {
+	violations := map[int][]Violation{}
+
+	for i, commit := range commits {
+		message := ParseMessage(commit.Message)
+		prefixes, target := splitAutosquashSummary(message.Summary)
+
+		if prefixes == "" {
+			continue
+		}
+
+		kind := autosquashKind(prefixes)
+		end := utf8.RuneCountInString(strings.TrimSuffix(prefixes, " ")) + 1
+
+		violations[i] = append(violations[i], Violation{
+			RuleID:  RuleAutosquash,
+			Message: fmt.Sprintf(`"%s" commit must be squashed, autosquash before merging`, kind),
+			Span:    newSpan(1, 1, end),
+		})
+
+		found := false
+
+		for _, older := range commits[i+1:] {
+			if isAutosquashTarget(target, older) {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			violations[i] = append(violations[i], Violation{
+				RuleID:  RuleAutosquash,
+				Message: fmt.Sprintf(`"%s" commit targets "%s" which can't be found earlier in range`, kind, target),
+				Span:    newSpan(1, utf8.RuneCountInString(prefixes)+1, utf8.RuneCountInString(message.Summary)+1),
+			})
+		}
+	}
+
+	return violations
+}
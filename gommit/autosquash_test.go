@@ -0,0 +1,135 @@
+package gommit
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitAutosquashSummary(t *testing.T) {
+	type scenario struct {
+		summary  string
+		prefixes string
+		target   string
+	}
+
+	scenarios := []scenario{
+		{"feat(file) : a feature", "", "feat(file) : a feature"},
+		{"fixup! feat(file) : a feature", "fixup! ", "feat(file) : a feature"},
+		{"squash! fixup! feat(file) : a feature", "squash! fixup! ", "feat(file) : a feature"},
+		{"amend! feat(file) : a feature", "amend! ", "feat(file) : a feature"},
+		{"fixup!feat(file) : a feature", "", "fixup!feat(file) : a feature"},
+	}
+
+	for _, s := range scenarios {
+		prefixes, target := splitAutosquashSummary(s.summary)
+
+		assert.Equal(t, s.prefixes, prefixes)
+		assert.Equal(t, s.target, target)
+	}
+}
+
+func TestCheckAutosquashCommits(t *testing.T) {
+	commit := func(message string) *object.Commit {
+		return &object.Commit{Hash: plumbing.ComputeHash(plumbing.CommitObject, []byte(message)), Message: message}
+	}
+
+	target := commit("feat(file) : a feature\n")
+	commits := []*object.Commit{
+		commit("fixup! " + target.Hash.String()[:7] + "\n"),
+		commit("squash! feat(file) : a feature\n\nmore details\n"),
+		commit("fixup! fixup! feat(file) : a feature\n"),
+		commit("fixup! feat(file) : a featu\n"),
+		commit("amend! feat(other) : another feature\n"),
+		target,
+		commit("fixup! feat(file) : a feature\n"),
+	}
+
+	violations := checkAutosquashCommits(commits)
+
+	assert.Len(t, violations, 6)
+
+	for _, i := range []int{0, 1, 2, 3} {
+		assert.Equal(t, []Violation{{RuleID: RuleAutosquash, Message: `"` + autosquashKind(ParseMessage(commits[i].Message).Summary) + `" commit must be squashed, autosquash before merging`, Span: violations[i][0].Span}}, violations[i])
+	}
+
+	assert.Equal(t, newSpan(1, 1, 14), violations[2][0].Span, "Must cover every prefix")
+	assert.Equal(t, []Violation{
+		{RuleID: RuleAutosquash, Message: `"amend!" commit must be squashed, autosquash before merging`, Span: newSpan(1, 1, 7)},
+		{RuleID: RuleAutosquash, Message: `"amend!" commit targets "feat(other) : another feature" which can't be found earlier in range`, Span: newSpan(1, 8, 37)},
+	}, violations[4])
+	assert.Equal(t, `"fixup!" commit targets "feat(file) : a feature" which can't be found earlier in range`, violations[6][1].Message, "Must look for a target in older commits only")
+}
+
+func TestMatchMessageQueryWithAnAutosquashCommit(t *testing.T) {
+	q := MessageQuery{
+		Message:  "fixup! feat(file) : a feature which is too long\n",
+		Matchers: map[string]string{"simple": "^feat\\(.*?\\) : .*"},
+		Options: Options{
+			CheckSummaryLength: true,
+			SummaryLength:      30,
+		},
+	}
+
+	m, err := MatchMessageQuery(q)
+
+	assert.NoError(t, err, "Must return no error")
+	assert.Equal(t, []Violation{
+		{RuleID: RuleSummaryLength, Message: "commit summary length is 40 characters, greater than 30 characters", Span: newSpan(1, 38, 48)},
+	}, m.Violations, "Must check target summary and report positions in original message")
+	assert.Equal(t, "fixup! feat(file) : a feature which is too long\n", m.Context["message"], "Must contains original message")
+}
+
+func TestMatchMessageQueryWithAnAutosquashCommitAndDenyMatchers(t *testing.T) {
+	q := MessageQuery{
+		Message:  "fixup! feat(file) : a feature\n",
+		Matchers: map[string]string{"simple": "^feat\\(.*?\\) : .*"},
+		Options: Options{
+			DenyMatchers: map[string]string{"fixup": "^fixup! ", "feature": "feature"},
+		},
+	}
+
+	m, err := MatchMessageQuery(q)
+
+	assert.NoError(t, err, "Must return no error")
+	assert.Equal(t, []Violation{
+		{RuleID: RuleDeny + ".feature", Message: `deny rule "feature" matched "feature"`, Span: newSpan(1, 23, 30)},
+		{RuleID: RuleDeny + ".fixup", Message: `deny rule "fixup" matched "fixup! "`, Span: newSpan(1, 1, 8)},
+	}, m.Violations, "Must run deny matchers against the message as it's written")
+}
+
+func TestMatchRangeQueryWithAutosquashCommits(t *testing.T) {
+	for _, filename := range []string{"../features/repo.sh", "../features/autosquash-commits.sh"} {
+		err := exec.Command(filename).Run()
+		if err != nil {
+			logrus.Fatal(err)
+		}
+	}
+
+	q := RangeQuery{
+		Path:     "testing-repository/",
+		From:     "test~3",
+		To:       "test",
+		Matchers: map[string]string{"simple": "^feat\\(.*?\\) : .*"},
+		Options: Options{
+			Severities: map[string]Severity{RuleAutosquash: SeverityWarning},
+		},
+	}
+
+	m, err := MatchRangeQuery(q)
+
+	assert.NoError(t, err, "Must return no errors")
+	assert.Len(t, *m, 2)
+	assert.Equal(t, []Violation{
+		{RuleID: RuleAutosquash, Severity: SeverityWarning, Message: `"squash!" commit must be squashed, autosquash before merging`, Span: newSpan(1, 1, 8)},
+		{RuleID: RuleAutosquash, Severity: SeverityWarning, Message: `"squash!" commit targets "feat(file9) : new file 9" which can't be found earlier in range`, Span: newSpan(1, 9, 33)},
+	}, (*m)[0].Violations)
+	assert.Equal(t, []Violation{
+		{RuleID: RuleAutosquash, Severity: SeverityWarning, Message: `"fixup!" commit must be squashed, autosquash before merging`, Span: newSpan(1, 1, 7)},
+	}, (*m)[1].Violations)
+	assert.NotEmpty(t, (*m)[1].Context["ID"])
+}
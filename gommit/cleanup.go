@@ -0,0 +1,98 @@
+package gommit
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CleanupMode defines how a message is cleaned up before being checked,
+// modes mimic git commit.cleanup modes
+type CleanupMode string
+
+// Cleanup modes, strip removes comment lines and whitespaces, whitespace removes
+// only whitespaces, scissors removes whitespaces and everything from the scissors
+// line and verbatim doesn't change the message. Strip also removes everything
+// from the scissors line as git does when a commit is made with --verbose
+const (
+	CleanupStrip      CleanupMode = "strip"
+	CleanupWhitespace CleanupMode = "whitespace"
+	CleanupScissors   CleanupMode = "scissors"
+	CleanupVerbatim   CleanupMode = "verbatim"
+)
+
+// scissorsLine is the line following the comment char marking the beginning
+// of the content git drops from a message
+const scissorsLine = "------------------------ >8 ------------------------"
+
+// ParseCleanupMode converts a cleanup mode name to a CleanupMode
+func ParseCleanupMode(name string) (CleanupMode, error) {
+	switch CleanupMode(name) {
+	case CleanupStrip, CleanupWhitespace, CleanupScissors, CleanupVerbatim:
+		return CleanupMode(name), nil
+	}
+
+	return CleanupVerbatim, fmt.Errorf(`cleanup mode "%s" doesn't exist, it must be strip, whitespace, scissors or verbatim`, name)
+}
+
+// CleanupMessage cleans up a message like git does before recording a commit,
+// commentChar is the character starting comment and scissors lines
+func CleanupMessage(message string, mode CleanupMode, commentChar string) string {
+	switch mode {
+	case CleanupStrip:
+		return stripSpace(cutAtScissors(message, commentChar), commentChar)
+	case CleanupScissors:
+		return stripSpace(cutAtScissors(message, commentChar), "")
+	case CleanupWhitespace:
+		return stripSpace(message, "")
+	default:
+		return message
+	}
+}
+
+// cutAtScissors removes the scissors line and everything following it
+func cutAtScissors(message string, commentChar string) string {
+	scissors := commentChar + " " + scissorsLine + "\n"
+
+	if strings.HasPrefix(message, scissors) {
+		return ""
+	}
+
+	if i := strings.Index(message, "\n"+scissors); i >= 0 {
+		return message[:i+1]
+	}
+
+	return message
+}
+
+// stripSpace removes trailing whitespaces of every line, leading and trailing
+// blank lines and collapses consecutive blank lines, lines starting with commentChar
+// are removed if commentChar is not empty
+func stripSpace(message string, commentChar string) string {
+	var b strings.Builder
+	blankLines := 0
+
+	for _, line := range strings.Split(message, "\n") {
+		if commentChar != "" && strings.HasPrefix(line, commentChar) {
+			continue
+		}
+
+		line = strings.TrimRight(line, " \t\r\v\f")
+
+		if line == "" {
+			blankLines++
+
+			continue
+		}
+
+		if blankLines > 0 && b.Len() > 0 {
+			b.WriteString("\n")
+		}
+
+		blankLines = 0
+
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
@@ -0,0 +1,102 @@
+package gommit
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCleanupMode(t *testing.T) {
+	for _, name := range []string{"strip", "whitespace", "scissors", "verbatim"} {
+		mode, err := ParseCleanupMode(name)
+
+		assert.NoError(t, err)
+		assert.Equal(t, CleanupMode(name), mode)
+	}
+
+	_, err := ParseCleanupMode("default")
+
+	assert.EqualError(t, err, `cleanup mode "default" doesn't exist, it must be strip, whitespace, scissors or verbatim`)
+}
+
+func TestCleanupMessage(t *testing.T) {
+	message := "\n\nfeat(file) : a feature  \n\n\n# a comment\nbody\t\n#\n\n; another comment\n"
+	message += "# ------------------------ >8 ------------------------\n"
+	message += "# Do not modify or remove the line above.\n"
+	message += "diff --git a/file b/file\n"
+
+	type scenario struct {
+		mode        CleanupMode
+		commentChar string
+		expected    string
+	}
+
+	scenarios := []scenario{
+		{CleanupVerbatim, "#", message},
+		{CleanupWhitespace, "#", "feat(file) : a feature\n\n# a comment\nbody\n#\n\n; another comment\n# ------------------------ >8 ------------------------\n# Do not modify or remove the line above.\ndiff --git a/file b/file\n"},
+		{CleanupScissors, "#", "feat(file) : a feature\n\n# a comment\nbody\n#\n\n; another comment\n"},
+		{CleanupStrip, "#", "feat(file) : a feature\n\nbody\n\n; another comment\n"},
+		{CleanupStrip, ";", "feat(file) : a feature\n\n# a comment\nbody\n#\n\n# ------------------------ >8 ------------------------\n# Do not modify or remove the line above.\ndiff --git a/file b/file\n"},
+	}
+
+	for _, s := range scenarios {
+		t.Run(string(s.mode)+" "+s.commentChar, func(t *testing.T) {
+			assert.Equal(t, s.expected, CleanupMessage(message, s.mode, s.commentChar))
+		})
+	}
+
+	assert.Equal(t, "", CleanupMessage("# ------------------------ >8 ------------------------\ndiff\n", CleanupScissors, "#"), "Must cut a message starting with scissors")
+	assert.Equal(t, "", CleanupMessage("# a comment\n\n", CleanupStrip, "#"))
+}
+
+func TestMatchMessageQueryWithCleanup(t *testing.T) {
+	err := exec.Command("../features/repo.sh").Run()
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	cmd := exec.Command("git", "config", "core.commentChar", ";")
+	cmd.Dir = "testing-repository"
+	assert.NoError(t, cmd.Run())
+
+	q := MessageQuery{
+		Path:     "testing-repository/",
+		Message:  "feat(file) : a feature\n; a comment\n",
+		Matchers: map[string]string{"simple": "^feat\\(.*?\\) : .*?\\n$"},
+		Cleanup:  CleanupStrip,
+	}
+
+	m, err := MatchMessageQuery(q)
+
+	assert.NoError(t, err, "Must return no error")
+	assert.True(t, IsZeroMatching(m), "Must strip comments using comment char defined in repository")
+
+	q.Cleanup = CleanupVerbatim
+
+	m, err = MatchMessageQuery(q)
+
+	assert.NoError(t, err, "Must return no error")
+	assert.Equal(t, []string{"no template match commit message"}, violationMessages(m.Violations))
+
+	assert.NoError(t, os.MkdirAll("testing-repository/subdirectory", 0o755))
+
+	q.Path = "testing-repository/subdirectory"
+	q.Cleanup = CleanupStrip
+
+	m, err = MatchMessageQuery(q)
+
+	assert.NoError(t, err, "Must return no error")
+	assert.True(t, IsZeroMatching(m), "Must look up comment char from a repository subdirectory")
+
+	q.Path = "testtesttest"
+	q.Message = "feat(file) : a feature\n# a comment\n"
+	q.Cleanup = CleanupStrip
+
+	m, err = MatchMessageQuery(q)
+
+	assert.NoError(t, err, "Must return no error")
+	assert.True(t, IsZeroMatching(m), "Must fallback to default comment char outside a repository")
+}
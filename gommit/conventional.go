@@ -0,0 +1,152 @@
+package gommit
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// conventionalSummaryRegexp splits a summary loosely into conventional commits parts,
+// it's permissive to be able to report every violation and not only the first one
+var conventionalSummaryRegexp = regexp.MustCompile(`^([^\s(!:]*)(\(([^)]*)\))?(!)?(:[ \t]*)?(.*)$`)
+
+// conventionalTypeRegexp matches a valid conventional commits type
+var conventionalTypeRegexp = regexp.MustCompile(`^[A-Za-z]+$`)
+
+// conventionalFooterRegexp matches a footer line, breaking change tokens are case insensitive to detect
+// lower case breaking change footers, and they may be followed by ":" alone to detect empty ones
+var conventionalFooterRegexp = regexp.MustCompile(`^(?:([A-Za-z0-9-]+)(?:: | #)|((?i:BREAKING[ -]CHANGE))(?::[ \t]*| #))(.*)$`)
+
+// conventionalSpacedTokenRegexp matches a footer line whose token contains whitespaces
+var conventionalSpacedTokenRegexp = regexp.MustCompile(`^([A-Za-z0-9-]+(?: [A-Za-z0-9-]+)+): `)
+
+// ConventionalCommit represents a commit message parsed following
+// conventional commits 1.0 specification
+type ConventionalCommit struct {
+	Type        string
+	Scope       string
+	Breaking    bool
+	Description string
+	Body        []Paragraph
+	Footers     []Trailer
+}
+
+// ParseConventionalCommit parses a message following conventional commits 1.0 specification,
+// it returns every violation of the specification found
+func ParseConventionalCommit(message *Message) (*ConventionalCommit, []Violation) {
+	commit := ConventionalCommit{}
+	violations := []Violation{}
+
+	chunks := conventionalSummaryRegexp.FindStringSubmatch(message.Summary)
+	indexes := conventionalSummaryRegexp.FindStringSubmatchIndex(message.Summary)
+	commit.Type = chunks[1]
+	commit.Scope = chunks[3]
+	commit.Breaking = chunks[4] == "!"
+	commit.Description = strings.TrimSpace(chunks[6])
+
+	// groupSpan returns the span of a regexp group in summary, an empty span
+	// right before description is returned for a missing group
+	groupSpan := func(group int) Span {
+		start, end := indexes[group*2], indexes[group*2+1]
+
+		if start < 0 {
+			start, end = indexes[12], indexes[12]
+		}
+
+		return newSpan(1, utf8.RuneCountInString(message.Summary[:start])+1, utf8.RuneCountInString(message.Summary[:end])+1)
+	}
+
+	switch {
+	case commit.Type == "":
+		violations = append(violations, Violation{Message: "summary must start with a type", Span: groupSpan(1)})
+	case !conventionalTypeRegexp.MatchString(commit.Type):
+		violations = append(violations, Violation{Message: fmt.Sprintf(`type "%s" must contain only letters`, commit.Type), Span: groupSpan(1)})
+	}
+
+	if chunks[2] != "" && strings.TrimSpace(commit.Scope) == "" {
+		violations = append(violations, Violation{Message: "scope must not be empty when parenthesis are provided", Span: groupSpan(2)})
+	}
+
+	if chunks[5] != ": " {
+		violations = append(violations, Violation{Message: `type or scope must be followed by ": "`, Span: groupSpan(5), Suggestion: ": "})
+	}
+
+	if commit.Description == "" {
+		violations = append(violations, Violation{Message: "description must not be empty", Span: groupSpan(6)})
+	}
+
+	paragraphs := splitParagraphs(message.Lines, 1)
+
+	if len(paragraphs) > 0 && message.Separator == 0 {
+		violations = append(violations, Violation{Message: "body must be separated from description by a blank line", Span: message.lineSpan(2)})
+	}
+
+	if len(paragraphs) > 0 && conventionalFooterRegexp.MatchString(paragraphs[len(paragraphs)-1].Lines[0]) {
+		footers, footerViolations := parseConventionalFooters(paragraphs[len(paragraphs)-1])
+		commit.Footers = footers
+		violations = append(violations, footerViolations...)
+		paragraphs = paragraphs[:len(paragraphs)-1]
+	}
+
+	commit.Body = paragraphs
+
+	for _, footer := range commit.Footers {
+		if footer.Key == "BREAKING CHANGE" || footer.Key == "BREAKING-CHANGE" {
+			commit.Breaking = true
+		}
+	}
+
+	return &commit, violations
+}
+
+// parseConventionalFooters extracts footers from the last paragraph of a message, lines
+// not starting a footer are continuation of the previous footer value
+func parseConventionalFooters(paragraph Paragraph) ([]Trailer, []Violation) {
+	footers := []Trailer{}
+	violations := []Violation{}
+
+	for i, line := range paragraph.Lines {
+		chunks := conventionalFooterRegexp.FindStringSubmatch(line)
+
+		if chunks == nil {
+			if spaced := conventionalSpacedTokenRegexp.FindStringSubmatch(line); spaced != nil {
+				violations = append(violations, Violation{
+					Message:    fmt.Sprintf(`footer token "%s" must use "-" in place of whitespaces`, spaced[1]),
+					Span:       newSpan(paragraph.Line+i, 1, utf8.RuneCountInString(spaced[1])+1),
+					Suggestion: strings.ReplaceAll(spaced[1], " ", "-"),
+				})
+			}
+
+			footers[len(footers)-1].Value += "\n" + line
+
+			continue
+		}
+
+		token := chunks[1] + chunks[2]
+
+		for _, expected := range []string{"BREAKING CHANGE", "BREAKING-CHANGE"} {
+			if strings.EqualFold(token, expected) && token != expected {
+				violations = append(violations, Violation{
+					Message:    fmt.Sprintf(`footer token "%s" must be written "%s"`, token, expected),
+					Span:       newSpan(paragraph.Line+i, 1, len(token)+1),
+					Suggestion: expected,
+				})
+				token = expected
+			}
+		}
+
+		footers = append(footers, Trailer{Key: token, Value: chunks[3], Line: paragraph.Line + i})
+	}
+
+	for _, footer := range footers {
+		if (footer.Key == "BREAKING CHANGE" || footer.Key == "BREAKING-CHANGE") && strings.TrimSpace(footer.Value) == "" {
+			violations = append(violations, Violation{
+				Message: fmt.Sprintf("%s footer must contain a description", footer.Key),
+				Span:    newSpan(footer.Line, 1, len(footer.Key)+1),
+			})
+		}
+	}
+
+	return footers, violations
+}
@@ -0,0 +1,138 @@
+package gommit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseConventionalCommit(t *testing.T) {
+	type scenario struct {
+		name    string
+		message string
+		test    func(*ConventionalCommit, []Violation)
+	}
+
+	scenarios := []scenario{
+		{
+			"Summary only",
+			"feat: add a feature\n",
+			func(c *ConventionalCommit, violations []Violation) {
+				assert.Empty(t, violations)
+				assert.Equal(t, &ConventionalCommit{Type: "feat", Description: "add a feature", Body: []Paragraph{}}, c)
+			},
+		},
+		{
+			"Summary with scope and breaking marker",
+			"feat(api)!: drop an endpoint\n",
+			func(c *ConventionalCommit, violations []Violation) {
+				assert.Empty(t, violations)
+				assert.Equal(t, "feat", c.Type)
+				assert.Equal(t, "api", c.Scope)
+				assert.True(t, c.Breaking)
+				assert.Equal(t, "drop an endpoint", c.Description)
+			},
+		},
+		{
+			"Body and footers",
+			"fix(parser): handle empty input\n\nEmpty input made the parser panic.\n\nBREAKING CHANGE: an error is returned\n  instead of a nil value\nReviewed-by: John Doe\nRefs #123\n",
+			func(c *ConventionalCommit, violations []Violation) {
+				assert.Empty(t, violations)
+				assert.True(t, c.Breaking)
+				assert.Equal(t, []Paragraph{{Line: 3, Lines: []string{"Empty input made the parser panic."}}}, c.Body)
+				assert.Equal(t, []Trailer{
+					{Key: "BREAKING CHANGE", Value: "an error is returned\n  instead of a nil value", Line: 5},
+					{Key: "Reviewed-by", Value: "John Doe", Line: 7},
+					{Key: "Refs", Value: "123", Line: 8},
+				}, c.Footers)
+			},
+		},
+		{
+			"Missing type",
+			": add a feature",
+			func(c *ConventionalCommit, violations []Violation) {
+				assert.Equal(t, []string{"summary must start with a type"}, violationMessages(violations))
+			},
+		},
+		{
+			"Invalid type",
+			"feat2: add a feature",
+			func(c *ConventionalCommit, violations []Violation) {
+				assert.Equal(t, []string{`type "feat2" must contain only letters`}, violationMessages(violations))
+				assert.Equal(t, newSpan(1, 1, 6), violations[0].Span)
+			},
+		},
+		{
+			"Empty scope",
+			"feat(): add a feature",
+			func(c *ConventionalCommit, violations []Violation) {
+				assert.Equal(t, []string{"scope must not be empty when parenthesis are provided"}, violationMessages(violations))
+			},
+		},
+		{
+			"Missing separator and description",
+			"feat(api)",
+			func(c *ConventionalCommit, violations []Violation) {
+				assert.Equal(t, []string{`type or scope must be followed by ": "`, "description must not be empty"}, violationMessages(violations))
+				assert.Equal(t, newSpan(1, 10, 10), violations[0].Span)
+				assert.Equal(t, ": ", violations[0].Suggestion)
+			},
+		},
+		{
+			"Missing blank line before body",
+			"feat: add a feature\nbody\n",
+			func(c *ConventionalCommit, violations []Violation) {
+				assert.Equal(t, []string{"body must be separated from description by a blank line"}, violationMessages(violations))
+			},
+		},
+		{
+			"Breaking change footer without description",
+			"feat!: x\n\nBREAKING CHANGE:\n",
+			func(c *ConventionalCommit, violations []Violation) {
+				assert.Equal(t, []Violation{{Message: "BREAKING CHANGE footer must contain a description", Span: newSpan(3, 1, 16)}}, violations)
+				assert.True(t, c.Breaking)
+				assert.Equal(t, []Trailer{{Key: "BREAKING CHANGE", Value: "", Line: 3}}, c.Footers)
+				assert.Empty(t, c.Body)
+			},
+		},
+		{
+			"Invalid footers",
+			"feat: add a feature\n\nbreaking change: something\nReviewed by: John Doe\nBREAKING-CHANGE: \n",
+			func(c *ConventionalCommit, violations []Violation) {
+				assert.Equal(t, []string{
+					`footer token "breaking change" must be written "BREAKING CHANGE"`,
+					`footer token "Reviewed by" must use "-" in place of whitespaces`,
+					"BREAKING-CHANGE footer must contain a description",
+				}, violationMessages(violations))
+				assert.Equal(t, Violation{Message: `footer token "breaking change" must be written "BREAKING CHANGE"`, Span: newSpan(3, 1, 16), Suggestion: "BREAKING CHANGE"}, violations[0])
+				assert.Equal(t, Violation{Message: `footer token "Reviewed by" must use "-" in place of whitespaces`, Span: newSpan(4, 1, 12), Suggestion: "Reviewed-by"}, violations[1])
+				assert.Equal(t, newSpan(5, 1, 16), violations[2].Span)
+			},
+		},
+		{
+			"Parenthesis in description",
+			"feat: add a feature (experimental)",
+			func(c *ConventionalCommit, violations []Violation) {
+				assert.Empty(t, violations)
+				assert.Equal(t, "", c.Scope)
+				assert.Equal(t, "add a feature (experimental)", c.Description)
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			s.test(ParseConventionalCommit(ParseMessage(s.message)))
+		})
+	}
+}
+
+func violationMessages(violations []Violation) []string {
+	s := []string{}
+
+	for _, violation := range violations {
+		s = append(s, violation.Message)
+	}
+
+	return s
+}
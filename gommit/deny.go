@@ -0,0 +1,59 @@
+package gommit
+
+import (
+	"fmt"
+	"time"
+)
+
+// findDeniedContent returns a violation for every match of a deny matcher in a message,
+// violations are identified by "deny.<name>", a matcher timing out produces a timeout violation
+func findDeniedContent(message string, denyMatchers matcherSet, timeout time.Duration) []Violation {
+	violations := []Violation{}
+	runes := []rune(message)
+
+	for _, matcher := range denyMatchers {
+		name := matcher.name
+		r := matcher.regexp
+
+		m, err := r.FindStringMatch(message)
+
+		for err == nil && m != nil {
+			violations = append(violations, Violation{
+				RuleID:  RuleDeny + "." + name,
+				Message: fmt.Sprintf(`deny rule "%s" matched "%s"`, name, m.String()),
+				Span:    Span{Start: runePosition(runes, m.Index), End: runePosition(runes, m.Index+m.Length)},
+			})
+
+			if m.Length == 0 {
+				break
+			}
+
+			m, err = r.FindNextMatch(m)
+		}
+
+		if err != nil {
+			violations = append(violations, matchTimeoutViolation(RuleDeny+"."+name, timeout))
+		}
+	}
+
+	return violations
+}
+
+// runePosition converts a rune index into a position
+func runePosition(runes []rune, index int) Position {
+	line := 1
+	column := 1
+
+	for _, r := range runes[:index] {
+		if r == '\n' {
+			line++
+			column = 1
+
+			continue
+		}
+
+		column++
+	}
+
+	return Position{Line: line, Column: column}
+}
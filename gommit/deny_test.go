@@ -0,0 +1,35 @@
+package gommit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindDeniedContent(t *testing.T) {
+	denyMatchers := mustCompileMatcherSet(map[string]string{
+		"wip":      "(?i)\\bwip\\b",
+		"fixup":    "^fixup! ",
+		"hostname": "[a-z]+\\.internal\\.example\\.com",
+	})
+
+	assert.Empty(t, findDeniedContent("feat(file) : a feature\n", denyMatchers, 0))
+	assert.Equal(t, []string{
+		`deny rule "hostname" matched "db.internal.example.com"`,
+		`deny rule "wip" matched "WIP"`,
+		`deny rule "wip" matched "wip"`,
+	}, violationMessages(findDeniedContent("feat(file) : WIP a feature\n\nconnect to db.internal.example.com\nwip\n", denyMatchers, 0)))
+	assert.Equal(t, []string{
+		`deny rule "fixup" matched "fixup! "`,
+	}, violationMessages(findDeniedContent("fixup! feat(file) : a feature", denyMatchers, 0)))
+	assert.Equal(t, []Violation{
+		{RuleID: "deny.wip", Message: `deny rule "wip" matched "wip"`, Span: newSpan(1, 10, 13)},
+	}, findDeniedContent("résumé : wip", denyMatchers, 0), "Must count columns in characters")
+
+	violations := findDeniedContent("feat(file) : WIP a feature\n\nconnect to db.internal.example.com\nwip\n", denyMatchers, 0)
+
+	assert.Equal(t, "deny.hostname", violations[0].RuleID, "Must identify violation with deny matcher name")
+	assert.Equal(t, newSpan(3, 12, 35), violations[0].Span)
+	assert.Equal(t, newSpan(1, 14, 17), violations[1].Span)
+	assert.Equal(t, newSpan(4, 1, 4), violations[2].Span)
+}
@@ -0,0 +1,187 @@
+package gommit
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// ruleSimilarSummary identifies near-duplicate summaries, they're part of the duplicate summary rule
+const ruleSimilarSummary = RuleDuplicateSummary + ".similar"
+
+// shortIDLength is the length of commit IDs listed in violations
+const shortIDLength = 7
+
+// normalizeSummary lower cases a summary, collapses its whitespaces and removes its final period
+// so summaries differing only by those details are considered equal
+func normalizeSummary(summary string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.Join(strings.Fields(summary), " ")), ".")
+}
+
+// similarSummaries returns true if similarity of two summaries reaches threshold, similarity
+// goes from 0 for completely different summaries to 1 for identical ones
+func similarSummaries(a []rune, b []rune, threshold float64) bool {
+	length := max(len(a), len(b))
+
+	if length == 0 {
+		return true
+	}
+
+	limit := int((1-threshold)*float64(length)) + 1
+
+	return 1-float64(boundedLevenshteinDistance(a, b, limit))/float64(length) >= threshold
+}
+
+// boundedLevenshteinDistance computes the levenshtein distance of two rune slices as long as it doesn't
+// exceed limit, only cells close enough to the diagonal are computed and limit+1 is returned as soon
+// as the distance goes over limit
+func boundedLevenshteinDistance(a []rune, b []rune, limit int) int {
+	exceeded := limit + 1
+
+	if max(len(a), len(b))-min(len(a), len(b)) > limit {
+		return exceeded
+	}
+
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+
+	for j := range previous {
+		previous[j] = min(j, exceeded)
+	}
+
+	for i := 1; i <= len(a); i++ {
+		low, high := max(1, i-limit), min(len(b), i+limit)
+		current[low-1] = exceeded
+
+		if low == 1 {
+			current[0] = min(i, exceeded)
+		}
+
+		rowMin := current[low-1]
+
+		for j := low; j <= high; j++ {
+			cost := 1
+
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost, exceeded)
+			rowMin = min(rowMin, current[j])
+		}
+
+		if high < len(b) {
+			current[high+1] = exceeded
+		}
+
+		if rowMin > limit {
+			return exceeded
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
+
+// shortID returns the abbreviated ID of a commit
+func shortID(commit *object.Commit) string {
+	return commit.Hash.String()[:shortIDLength]
+}
+
+// duplicateSummaryRule reports commits of a range sharing the same summary, and optionally
+// summaries whose similarity reaches threshold, merge and autosquash commits are ignored
+// as their summaries are expected to look alike
+type duplicateSummaryRule struct {
+	threshold float64
+}
+
+func (r duplicateSummaryRule) ID() string {
+	return RuleDuplicateSummary
+}
+
+func (r duplicateSummaryRule) Check(message *Message, commit *object.Commit) []Violation {
+	return nil
+}
+
+// summaryEntry is a summary checked against other summaries of a range
+type summaryEntry struct {
+	index      int
+	summary    string
+	normalized string
+	runes      []rune
+}
+
+func (r duplicateSummaryRule) CheckRange(commits []*object.Commit) map[int][]Violation {
+	violations := map[int][]Violation{}
+	entries := []summaryEntry{}
+	groups := map[string][]int{}
+
+	for i, commit := range commits {
+		summary := ParseMessage(commit.Message).Summary
+
+		if prefixes, _ := splitAutosquashSummary(summary); prefixes != "" || isMergeCommit(commit) {
+			continue
+		}
+
+		normalized := normalizeSummary(summary)
+		entries = append(entries, summaryEntry{index: i, summary: summary, normalized: normalized, runes: []rune(normalized)})
+		groups[normalized] = append(groups[normalized], i)
+	}
+
+	similars := r.findSimilarSummaries(entries, commits)
+
+	for _, entry := range entries {
+		commit := commits[entry.index]
+		span := newSpan(1, 1, utf8.RuneCountInString(entry.summary)+1)
+		duplicates := []string{}
+
+		for _, j := range groups[entry.normalized] {
+			if j != entry.index {
+				duplicates = append(duplicates, shortID(commits[j]))
+			}
+		}
+
+		if len(duplicates) > 0 {
+			violations[entry.index] = append(violations[entry.index], Violation{
+				Message: fmt.Sprintf(`summary "%s" of commit %s is also used by commits %s`, entry.summary, shortID(commit), strings.Join(duplicates, ", ")),
+				Span:    span,
+			})
+		}
+
+		if len(similars[entry.index]) > 0 {
+			violations[entry.index] = append(violations[entry.index], Violation{
+				RuleID:  ruleSimilarSummary,
+				Message: fmt.Sprintf(`summary "%s" of commit %s is similar to %s`, entry.summary, shortID(commit), strings.Join(similars[entry.index], ", ")),
+				Span:    span,
+			})
+		}
+	}
+
+	return violations
+}
+
+// findSimilarSummaries returns for every commit index the summaries of other commits whose similarity
+// reaches threshold, exact duplicates excluded, every pair is compared so it only runs when a threshold is set
+func (r duplicateSummaryRule) findSimilarSummaries(entries []summaryEntry, commits []*object.Commit) map[int][]string {
+	similars := map[int][]string{}
+
+	if r.threshold <= 0 {
+		return similars
+	}
+
+	for i, a := range entries {
+		for _, b := range entries[i+1:] {
+			if a.normalized == b.normalized || !similarSummaries(a.runes, b.runes, r.threshold) {
+				continue
+			}
+
+			similars[a.index] = append(similars[a.index], fmt.Sprintf(`"%s" in %s`, b.summary, shortID(commits[b.index])))
+			similars[b.index] = append(similars[b.index], fmt.Sprintf(`"%s" in %s`, a.summary, shortID(commits[a.index])))
+		}
+	}
+
+	return similars
+}
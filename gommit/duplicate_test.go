@@ -0,0 +1,135 @@
+package gommit
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeSummary(t *testing.T) {
+	assert.Equal(t, "fix tests", normalizeSummary("Fix  tests."))
+	assert.Equal(t, "wip", normalizeSummary(" WIP "))
+}
+
+func TestSimilarSummaries(t *testing.T) {
+	assert.True(t, similarSummaries([]rune("fix tests"), []rune("fix tests"), 1))
+	assert.True(t, similarSummaries([]rune("fix tests"), []rune("fix test"), 0.88))
+	assert.False(t, similarSummaries([]rune("fix tests"), []rune("fix test"), 0.9))
+	assert.True(t, similarSummaries([]rune("fix test 1"), []rune("fix test 2"), 0.9), "Must include threshold")
+	assert.False(t, similarSummaries([]rune("fix tests"), []rune("tests"), 0.8))
+	assert.False(t, similarSummaries([]rune("abc"), []rune("def"), 0.1))
+	assert.True(t, similarSummaries([]rune(""), []rune(""), 1))
+}
+
+func TestBoundedLevenshteinDistance(t *testing.T) {
+	assert.Equal(t, 3, boundedLevenshteinDistance([]rune("kitten"), []rune("sitting"), 3))
+	assert.Equal(t, 3, boundedLevenshteinDistance([]rune("kitten"), []rune("sitting"), 10))
+	assert.Equal(t, 3, boundedLevenshteinDistance([]rune("kitten"), []rune("sitting"), 2), "Must return limit+1 when limit is exceeded")
+	assert.Equal(t, 2, boundedLevenshteinDistance([]rune(""), []rune("test"), 1))
+	assert.Equal(t, 1, boundedLevenshteinDistance([]rune("café"), []rune("cafe"), 1))
+	assert.Equal(t, 2, boundedLevenshteinDistance([]rune("frontned"), []rune("frontend"), 2))
+}
+
+func TestDuplicateSummaryRule(t *testing.T) {
+	commit := func(message string, parents int) *object.Commit {
+		return &object.Commit{
+			Hash:         plumbing.ComputeHash(plumbing.CommitObject, []byte(message)),
+			Message:      message,
+			ParentHashes: make([]plumbing.Hash, parents),
+		}
+	}
+
+	commits := []*object.Commit{
+		commit("wip\n", 1),
+		commit("fix test\n", 1),
+		commit("Merge branch 'main'\n", 2),
+		commit("fixup! wip\n", 1),
+		commit("WIP.\n", 1),
+		commit("Merge branch 'main'\n\nagain\n", 2),
+		commit("fix tests\n", 1),
+		commit("wip\n\nmore work\n", 1),
+		commit("feat : a feature\n", 1),
+	}
+
+	type scenario struct {
+		name       string
+		threshold  float64
+		violations map[int][]Violation
+	}
+
+	scenarios := []scenario{
+		{
+			"Exact duplicates",
+			0,
+			map[int][]Violation{
+				0: {{Message: `summary "wip" of commit ` + shortID(commits[0]) + ` is also used by commits ` + shortID(commits[4]) + ", " + shortID(commits[7]), Span: newSpan(1, 1, 4)}},
+				4: {{Message: `summary "WIP." of commit ` + shortID(commits[4]) + ` is also used by commits ` + shortID(commits[0]) + ", " + shortID(commits[7]), Span: newSpan(1, 1, 5)}},
+				7: {{Message: `summary "wip" of commit ` + shortID(commits[7]) + ` is also used by commits ` + shortID(commits[0]) + ", " + shortID(commits[4]), Span: newSpan(1, 1, 4)}},
+			},
+		},
+		{
+			"Near duplicates",
+			0.8,
+			map[int][]Violation{
+				0: {{Message: `summary "wip" of commit ` + shortID(commits[0]) + ` is also used by commits ` + shortID(commits[4]) + ", " + shortID(commits[7]), Span: newSpan(1, 1, 4)}},
+				1: {{RuleID: "duplicate-summary.similar", Message: `summary "fix test" of commit ` + shortID(commits[1]) + ` is similar to "fix tests" in ` + shortID(commits[6]), Span: newSpan(1, 1, 9)}},
+				4: {{Message: `summary "WIP." of commit ` + shortID(commits[4]) + ` is also used by commits ` + shortID(commits[0]) + ", " + shortID(commits[7]), Span: newSpan(1, 1, 5)}},
+				6: {{RuleID: "duplicate-summary.similar", Message: `summary "fix tests" of commit ` + shortID(commits[6]) + ` is similar to "fix test" in ` + shortID(commits[1]), Span: newSpan(1, 1, 10)}},
+				7: {{Message: `summary "wip" of commit ` + shortID(commits[7]) + ` is also used by commits ` + shortID(commits[0]) + ", " + shortID(commits[4]), Span: newSpan(1, 1, 4)}},
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			assert.Equal(t, s.violations, duplicateSummaryRule{threshold: s.threshold}.CheckRange(commits))
+		})
+	}
+}
+
+func TestMatchRangeQueryWithDuplicateSummaries(t *testing.T) {
+	for _, filename := range []string{"../features/repo.sh", "../features/duplicate-summary-commits.sh"} {
+		err := exec.Command(filename).Run()
+		if err != nil {
+			logrus.Fatal(err)
+		}
+	}
+
+	q := RangeQuery{
+		Path:     "testing-repository/",
+		From:     "test~4",
+		To:       "test",
+		Matchers: map[string]string{"simple": "^feat\\(.*?\\) : .*"},
+		Options: Options{
+			CheckDuplicateSummaries: true,
+			SummarySimilarity:       0.9,
+			Severities:              map[string]Severity{"duplicate-summary.similar": SeverityWarning},
+		},
+	}
+
+	m, err := MatchRangeQuery(q)
+
+	assert.NoError(t, err, "Must return no errors")
+	assert.Len(t, *m, 4)
+
+	assert.Empty(t, (*m)[0].Violations)
+	assert.Len(t, (*m)[0].Suppressed, 2, "Must waive duplicates with a Gommit-Skip trailer")
+	assert.Equal(t, "tests are fixed in several steps", (*m)[0].Suppressed[0].SuppressionReason)
+
+	ID := func(i int) string {
+		return (*m)[i].Context["ID"][:shortIDLength]
+	}
+
+	assert.Equal(t, []Violation{
+		{RuleID: "duplicate-summary.similar", Severity: SeverityWarning, Message: `summary "feat(file) : fix test" of commit ` + ID(1) + ` is similar to "feat(file) : fix tests" in ` + ID(0) + `, "feat(file) : Fix tests." in ` + ID(2) + `, "feat(file) : fix tests" in ` + ID(3), Span: newSpan(1, 1, 22)},
+	}, (*m)[1].Violations)
+	assert.Equal(t, []Violation{
+		{RuleID: RuleDuplicateSummary, Message: `summary "feat(file) : Fix tests." of commit ` + ID(2) + ` is also used by commits ` + ID(0) + ", " + ID(3), Span: newSpan(1, 1, 24)},
+		{RuleID: "duplicate-summary.similar", Severity: SeverityWarning, Message: `summary "feat(file) : Fix tests." of commit ` + ID(2) + ` is similar to "feat(file) : fix test" in ` + ID(1), Span: newSpan(1, 1, 24)},
+	}, (*m)[2].Violations)
+	assert.Len(t, (*m)[3].Violations, 2)
+}
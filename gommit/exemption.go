@@ -0,0 +1,130 @@
+package gommit
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// Identity fields an exemption can match
+const (
+	identityAuthorName     = "author-name"
+	identityAuthorEmail    = "author-email"
+	identityCommitterName  = "committer-name"
+	identityCommitterEmail = "committer-email"
+)
+
+// Exemption applies to commits whose author and committer match its patterns, every
+// pattern defined must match. A commit matching an exemption without matchers is not
+// checked, otherwise its message must match one of those matchers in place of the query ones
+type Exemption struct {
+	AuthorName     string
+	AuthorEmail    string
+	CommitterName  string
+	CommitterEmail string
+	Matchers       map[string]string
+}
+
+// compiledExemption is an exemption whose patterns and matchers are compiled
+type compiledExemption struct {
+	name       string
+	identities matcherSet
+	matchers   matcherSet
+}
+
+// compileExemptions compiles exemptions sorted by name
+func compileExemptions(exemptions map[string]Exemption, options Options) ([]compiledExemption, error) {
+	compiled := []compiledExemption{}
+
+	for _, name := range slices.Sorted(maps.Keys(exemptions)) {
+		exemption := exemptions[name]
+		patterns := map[string]string{}
+
+		for field, pattern := range map[string]string{
+			identityAuthorName:     exemption.AuthorName,
+			identityAuthorEmail:    exemption.AuthorEmail,
+			identityCommitterName:  exemption.CommitterName,
+			identityCommitterEmail: exemption.CommitterEmail,
+		} {
+			if pattern != "" {
+				patterns[field] = pattern
+			}
+		}
+
+		if len(patterns) == 0 {
+			return nil, fmt.Errorf(`exemption "%s" must define at least one of %s, %s, %s or %s`, name, identityAuthorName, identityAuthorEmail, identityCommitterName, identityCommitterEmail)
+		}
+
+		identities, err := compileMatcherSet(patterns, options.MatchTimeout, `regexp "%s" defined for `+name+` exemption "%s" is not a valid regexp, please check the syntax`)
+		if err != nil {
+			return nil, err
+		}
+
+		matchers, err := compileMatcherSet(exemption.Matchers, options.MatchTimeout, `regexp "%s" identified by "%s" in `+name+` exemption matchers is not a valid regexp, please check the syntax`)
+		if err != nil {
+			return nil, err
+		}
+
+		compiled = append(compiled, compiledExemption{name: name, identities: identities, matchers: matchers})
+	}
+
+	return compiled, nil
+}
+
+// identityField returns the author or committer field of a commit an exemption pattern applies to
+func identityField(commit *object.Commit, field string) string {
+	switch field {
+	case identityAuthorName:
+		return commit.Author.Name
+	case identityAuthorEmail:
+		return commit.Author.Email
+	case identityCommitterName:
+		return commit.Committer.Name
+	default:
+		return commit.Committer.Email
+	}
+}
+
+// applies returns true if every identity pattern matches commit,
+// a pattern timing out is considered as not matching
+func (e compiledExemption) applies(commit *object.Commit) bool {
+	for _, identity := range e.identities {
+		if ok, err := identity.match(identityField(commit, identity.name)); err != nil || !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// exemptionRules holds rules commits matching an exemption are checked against,
+// rules are nil when commits are exempted from every check
+type exemptionRules struct {
+	exemption compiledExemption
+	rules     []Rule
+}
+
+// buildExemptionRules creates rules of every exemption, built-in rules of an exemption
+// defining matchers use them in place of query matchers, conventional commits
+// specification is not enforced as those matchers define the expected format
+func buildExemptionRules(matchers *compiledMatchers, options Options, customRules []Rule) []exemptionRules {
+	exemptions := []exemptionRules{}
+
+	for _, exemption := range matchers.exemptions {
+		e := exemptionRules{exemption: exemption}
+
+		if len(exemption.matchers) > 0 {
+			m := *matchers
+			m.templates = exemption.matchers
+			o := options
+			o.ConventionalCommits = false
+			e.rules = append(buildRules(&m, o), customRules...)
+		}
+
+		exemptions = append(exemptions, e)
+	}
+
+	return exemptions
+}
@@ -0,0 +1,132 @@
+package gommit
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompileExemptions(t *testing.T) {
+	exemptions, err := compileExemptions(map[string]Exemption{
+		"renovate":   {AuthorName: `^renovate\[bot\]$`},
+		"dependabot": {AuthorName: `^dependabot\[bot\]$`, CommitterEmail: `@github\.com$`, Matchers: map[string]string{"bump": "^Bump "}},
+	}, Options{})
+
+	assert.NoError(t, err)
+	assert.Len(t, exemptions, 2)
+	assert.Equal(t, "dependabot", exemptions[0].name, "Must sort exemptions by name")
+	assert.Equal(t, []string{identityAuthorName, identityCommitterEmail}, []string{exemptions[0].identities[0].name, exemptions[0].identities[1].name})
+	assert.Equal(t, "bump", exemptions[0].matchers[0].name)
+	assert.Empty(t, exemptions[1].matchers)
+
+	type scenario struct {
+		exemptions map[string]Exemption
+		err        string
+	}
+
+	scenarios := []scenario{
+		{map[string]Exemption{"bot": {Matchers: map[string]string{"bump": "^Bump "}}}, `exemption "bot" must define at least one of author-name, author-email, committer-name or committer-email`},
+		{map[string]Exemption{"bot": {AuthorEmail: "(bot"}}, `regexp "(bot" defined for bot exemption "author-email" is not a valid regexp, please check the syntax`},
+		{map[string]Exemption{"bot": {AuthorEmail: "bot", Matchers: map[string]string{"bump": "(Bump"}}}, `regexp "(Bump" identified by "bump" in bot exemption matchers is not a valid regexp, please check the syntax`},
+	}
+
+	for _, s := range scenarios {
+		_, err := compileExemptions(s.exemptions, Options{})
+
+		assert.EqualError(t, err, s.err)
+	}
+}
+
+func TestCompiledExemptionApplies(t *testing.T) {
+	exemption := compiledExemption{identities: mustCompileMatcherSet(map[string]string{
+		identityAuthorName:     `^dependabot\[bot\]$`,
+		identityAuthorEmail:    `\+dependabot\[bot\]@users\.noreply\.github\.com$`,
+		identityCommitterName:  `^GitHub$`,
+		identityCommitterEmail: `^noreply@github\.com$`,
+	})}
+
+	commit := &object.Commit{
+		Author:    object.Signature{Name: "dependabot[bot]", Email: "49699333+dependabot[bot]@users.noreply.github.com"},
+		Committer: object.Signature{Name: "GitHub", Email: "noreply@github.com"},
+	}
+
+	assert.True(t, exemption.applies(commit))
+
+	commit.Committer.Email = "john@example.com"
+
+	assert.False(t, exemption.applies(commit), "Must match every identity pattern")
+}
+
+func TestMatchRangeQueryWithExemptions(t *testing.T) {
+	for _, filename := range []string{"../features/repo.sh", "../features/bot-commits.sh"} {
+		err := exec.Command(filename).Run()
+		if err != nil {
+			logrus.Fatal(err)
+		}
+	}
+
+	q := RangeQuery{
+		Path:     "testing-repository/",
+		From:     "test~3",
+		To:       "test",
+		Matchers: map[string]string{"simple": "^feat\\(.*?\\) : .*"},
+		Options: Options{
+			Exemptions: map[string]Exemption{
+				"dependabot": {AuthorName: `^dependabot\[bot\]$`, Matchers: map[string]string{"bump": "^Bump \\S+ from \\S+ to \\S+\n"}},
+				"renovate":   {AuthorEmail: `\+renovate\[bot\]@users\.noreply\.github\.com$`},
+			},
+		},
+	}
+
+	m, err := MatchRangeQuery(q)
+
+	assert.NoError(t, err, "Must return no errors")
+	assert.Len(t, *m, 3)
+
+	assert.Equal(t, "Bump everything\n", (*m)[0].Context["message"])
+	assert.Equal(t, []Violation{{RuleID: RuleTemplate, Message: "no template match commit message"}}, (*m)[0].Violations, "Must check commits of humans against query matchers")
+	assert.Empty(t, (*m)[0].Exemption)
+
+	assert.Equal(t, "Update dependency lodash to v5\n", (*m)[1].Context["message"])
+	assert.NotEmpty(t, (*m)[1].Context["ID"])
+	assert.Equal(t, "renovate", (*m)[1].Exemption)
+	assert.True(t, IsExemptedMatching((*m)[1]), "Must not check exempted commits")
+
+	assert.Equal(t, "dependabot", (*m)[2].Exemption)
+	assert.True(t, IsExemptedMatching((*m)[2]), "Must check commits against exemption matchers")
+}
+
+func TestMatchRangeQueryWithExemptionsAndIdentityChecks(t *testing.T) {
+	for _, filename := range []string{"../features/repo.sh", "../features/bot-commits.sh"} {
+		err := exec.Command(filename).Run()
+		if err != nil {
+			logrus.Fatal(err)
+		}
+	}
+
+	q := RangeQuery{
+		Path:     "testing-repository/",
+		From:     "test~3",
+		To:       "test~1",
+		Matchers: map[string]string{"simple": "^feat\\(.*?\\) : .*"},
+		Options: Options{
+			Exemptions: map[string]Exemption{
+				"renovate": {AuthorName: `^renovate\[bot\]$`},
+			},
+			PlaceholderNames: []string{"renovate[bot]"},
+		},
+	}
+
+	m, err := MatchRangeQuery(q)
+
+	assert.NoError(t, err, "Must return no errors")
+	assert.Len(t, *m, 2)
+	assert.Equal(t, "renovate", (*m)[0].Exemption)
+	assert.Equal(t, []Violation{
+		{RuleID: ruleIdentityName, Message: `author name "renovate[bot]" is a placeholder, configure user.name in git`},
+	}, (*m)[0].Violations, "Must check identity of commits exempted from every check")
+	assert.Equal(t, []Violation{{RuleID: RuleTemplate, Message: "no template match commit message"}}, (*m)[1].Violations)
+}
@@ -2,52 +2,101 @@ package gommit
 
 import (
 	"errors"
-	"fmt"
+	"regexp"
 	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
 
-	"github.com/dlclark/regexp2"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing/object"
 
 	"github.com/antham/gommit/reference"
 )
 
-// Matching represents an error when something goes wrong
+// urlRegexp matches an URL in a commit message line
+var urlRegexp = regexp.MustCompile(`[a-zA-Z][a-zA-Z0-9+.-]*://\S+`)
+
+// Matching represents violations found in a commit message,
+// Suppressed are violations waived by a Gommit-Skip trailer,
+// Exemption is the name of the exemption applying to a commit if any
 type Matching struct {
-	Context      map[string]string
-	MessageError error
-	SummaryError error
+	Context    map[string]string
+	Violations []Violation
+	Suppressed []Violation
+	Exemption  string
 }
 
-// CommitQuery to retrieves a commit and do checking
+// CommitQuery to retrieves a commit and do checking,
+// Rules are custom rules run in addition to built-in ones
 type CommitQuery struct {
 	Path     string
 	ID       string
 	Matchers map[string]string
 	Options  Options
+	Rules    []Rule
 }
 
-// RangeQuery to retrieves commits and do checking
+// RangeQuery to retrieves commits and do checking,
+// Rules are custom rules run in addition to built-in ones, Jobs is the number
+// of commits analyzed concurrently, commits are analyzed one at a time if it's lower than 2,
+// custom rules must be safe for concurrent use when Jobs is greater than 1
 type RangeQuery struct {
 	Path     string
 	From     string
 	To       string
 	Matchers map[string]string
 	Options  Options
+	Rules    []Rule
+	Jobs     int
 }
 
-// MessageQuery to check only commit message
+// MessageQuery to check only commit message, Path is optional and only used
+// for checks requiring the repository, Rules are custom rules run in addition to built-in ones,
+// Cleanup defines how message is cleaned up before being checked, it's kept verbatim by default
 type MessageQuery struct {
+	Path     string
 	Message  string
 	Matchers map[string]string
 	Options  Options
+	Rules    []Rule
+	Cleanup  CleanupMode
 }
 
 // Options represents options picked from configuration
 type Options struct {
-	CheckSummaryLength  bool
-	ExcludeMergeCommits bool
-	SummaryLength       int
+	CheckBodyLineLength     bool
+	CheckSignedOffBy        bool
+	CheckSummaryLength      bool
+	CheckSummarySeparator   bool
+	ConventionalCommits     bool
+	ExcludeMergeCommits     bool
+	BodyLineLength          int
+	SummaryLength           int
+	SummaryLengthMode       LengthMode
+	CheckDuplicateSummaries bool
+	SummarySimilarity       float64
+	Types                   []string
+	Scopes                  []string
+	RequiredTrailers        []string
+	ForbiddenTrailers       []string
+	TrailerPatterns         map[string]string
+	DenyMatchers            map[string]string
+	MergeMatchers           map[string]string
+	Exemptions              map[string]Exemption
+	IssueProjects           []string
+	IssueExemptTypes        []string
+	CheckBranchIssueKey     bool
+	CheckReverts            bool
+	RevertRequireReason     bool
+	RevertBypassMatchers    bool
+	AllowedEmailDomains     []string
+	PlaceholderNames        []string
+	CheckAuthorDate         bool
+	SignatureKeyring        string
+	AllowedSignersFile      string
+	Severities              map[string]Severity
+	MatchTimeout            time.Duration
 }
 
 // fetchCommits retrieves all commits in repository between 2 commits references
@@ -60,6 +109,32 @@ func fetchCommits(repoPath string, from string, to string) (*[]*object.Commit, e
 	return reference.FetchCommitInterval(repo, from, to)
 }
 
+// fetchCurrentBranch retrieves the branch checked out in repository,
+// repository is looked up in path parent directories too
+func fetchCurrentBranch(repoPath string) (string, error) {
+	repo, err := openRepository(repoPath)
+	if err != nil {
+		return "", err
+	}
+
+	return reference.FetchCurrentBranch(repo)
+}
+
+// fetchCommentChar retrieves the character starting comment lines in repository looked
+// up in path parent directories, git default is used when no repository is found
+func fetchCommentChar(repoPath string) (string, error) {
+	repo, err := openRepository(repoPath)
+	if errors.Is(err, git.ErrRepositoryNotExists) {
+		return "#", nil
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return reference.FetchCommentChar(repo)
+}
+
 // fetchCommit retrieve a single commit in repository from its ID
 func fetchCommit(repoPath string, ID string) (*object.Commit, error) {
 	repo, err := git.PlainOpen(repoPath)
@@ -70,88 +145,224 @@ func fetchCommit(repoPath string, ID string) (*object.Commit, error) {
 	return reference.FetchCommitByID(repo, ID)
 }
 
-// messageMatchTemplate tries to match a commit message against a regexp
-func messageMatchTemplate(message string, template string) bool {
-	r := regexp2.MustCompile(template, 0)
-
-	b, err := r.MatchString(message)
+// isValidSummaryLength returns true if summary length measured according to mode
+// is lower than summaryLength
+func isValidSummaryLength(summaryLength int, summary string, mode LengthMode) bool {
+	return measureLength(summary, mode) <= summaryLength
+}
 
-	return err == nil && b
+// hasValidSeparator returns true if summary is followed by exactly one blank line
+// or if message doesn't contain anything after summary
+func hasValidSeparator(message *Message) bool {
+	return (len(message.Body) == 0 && len(message.Trailers) == 0) || message.Separator == 1
 }
 
-// isValidSummaryLength returns true if message size length is lower than characters given by
-// summaryLength
-func isValidSummaryLength(summaryLength int, message string) bool {
-	chunks := strings.Split(message, "\n")
+// findLongBodyLines returns line numbers of body lines longer than bodyLineLength,
+// lines containing an URL and indented code blocks are ignored as they can't be wrapped
+func findLongBodyLines(bodyLineLength int, message *Message) []int {
+	lines := []int{}
+
+	for _, paragraph := range message.Body {
+		for i, line := range paragraph.Lines {
+			if utf8.RuneCountInString(line) <= bodyLineLength || isCodeBlockLine(line) || urlRegexp.MatchString(line) {
+				continue
+			}
 
-	return len(chunks) == 0 || len(chunks[0]) <= summaryLength
+			lines = append(lines, paragraph.Line+i)
+		}
+	}
+
+	return lines
+}
+
+// isCodeBlockLine returns true if a line is part of an indented code block
+func isCodeBlockLine(line string) bool {
+	return strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "\t")
 }
 
-// isMergeCommit returns true if a commit is a merge commit
+// isMergeCommit returns true if a commit is a merge commit, octopus merges included
 func isMergeCommit(commit *object.Commit) bool {
-	return commit.NumParents() == 2
+	return commit.NumParents() > 1
+}
+
+// RuleViolations returns violations produced by a rule, violations of
+// a rule like "deny.wip" are returned when asking for "deny"
+func (m *Matching) RuleViolations(ID string) []Violation {
+	violations := []Violation{}
+
+	for _, violation := range m.Violations {
+		if violation.RuleID == ID || strings.HasPrefix(violation.RuleID, ID+".") {
+			violations = append(violations, violation)
+		}
+	}
+
+	return violations
 }
 
 // IsZeroMatching checks if Matching struct equals zero
 func IsZeroMatching(matching *Matching) bool {
-	return len(matching.Context) == 0 && matching.MessageError == nil && matching.SummaryError == nil
+	return len(matching.Context) == 0 && len(matching.Violations) == 0 && len(matching.Suppressed) == 0 && matching.Exemption == ""
 }
 
-// analyzeMessage checks if a message match expectations
-func analyzeMessage(message string, matchers map[string]string, options Options) *Matching {
-	matching := Matching{}
-	matchTemplate := false
-	hasError := false
+// IsExemptedMatching checks if a Matching struct only reports a commit exempted from checks
+func IsExemptedMatching(matching *Matching) bool {
+	return matching.Exemption != "" && len(matching.Violations) == 0 && len(matching.Suppressed) == 0
+}
 
-	for _, matcher := range matchers {
-		t := messageMatchTemplate(message, matcher)
+// analyzeMessage checks if a message match expectations running every rule against it,
+// commit is nil when a message is checked on its own, violations waived by a Gommit-Skip
+// trailer are moved to suppressed violations. Autosquash commits like "fixup! <summary>"
+// are checked as if their summary was the one of the commit they target, except by deny
+// matchers which check the message as it's written, revert commits skip matcher rules
+// when options allow it
+func analyzeMessage(message string, commit *object.Commit, rules []Rule, options Options) *Matching {
+	matching := Matching{}
+	original := ParseMessage(message)
+	msg := original
+	prefixes, _ := splitAutosquashSummary(msg.Summary)
 
-		if t {
-			matchTemplate = true
-		}
+	if prefixes != "" {
+		msg = ParseMessage(strings.TrimPrefix(message, prefixes))
 	}
 
-	if options.CheckSummaryLength && !isValidSummaryLength(options.SummaryLength, message) {
-		hasError = true
-		matching.SummaryError = fmt.Errorf("commit summary length is greater than %d characters", options.SummaryLength)
+	suppressions, suppressionViolations := parseSuppressions(msg, rules)
+
+	for _, violation := range suppressionViolations {
+		violation.RuleID = RuleSuppression
+		violation.Severity = severityFor(violation.RuleID, violation.Severity, options)
+		matching.Violations = append(matching.Violations, violation)
 	}
 
-	if !matchTemplate {
-		hasError = true
-		matching.MessageError = errors.New("no template match commit message")
+	for _, rule := range rules {
+		if isBypassedRule(rule.ID(), msg, options) {
+			continue
+		}
+
+		checked, shift := msg, utf8.RuneCountInString(prefixes)
+
+		if rule.ID() == RuleDeny {
+			checked, shift = original, 0
+		}
+
+		for _, violation := range rule.Check(checked, commit) {
+			if violation.RuleID == "" {
+				violation.RuleID = rule.ID()
+			}
+
+			violation.Severity = severityFor(violation.RuleID, violation.Severity, options)
+			violation.Span = violation.Span.shift(1, shift)
+
+			if suppression, ok := findSuppression(violation, suppressions); ok {
+				violation.SuppressionReason = suppression.Reason
+				matching.Suppressed = append(matching.Suppressed, violation)
+
+				continue
+			}
+
+			matching.Violations = append(matching.Violations, violation)
+		}
 	}
 
-	if hasError {
+	if len(matching.Violations) > 0 || len(matching.Suppressed) > 0 {
 		matching.Context = map[string]string{"message": message}
 	}
 
 	return &matching
 }
 
-// analyzeCommit checks if a commit message match expectations
-func analyzeCommit(commit *object.Commit, matchers map[string]string, options Options) *Matching {
-	if options.ExcludeMergeCommits && isMergeCommit(commit) {
-		return &Matching{}
+// analyzeCommit checks if a commit message match expectations, commits matching an exemption
+// are checked against its rules, merge commits are checked against merge rules when they are
+// defined. Commits whose message is not checked are still checked against trust rules
+func analyzeCommit(commit *object.Commit, rules ruleSet, options Options) *Matching {
+	exemption, commitRules := rules.rulesFor(commit, options)
+	var m *Matching
+
+	if commitRules != nil {
+		m = analyzeMessage(commit.Message, commit, commitRules, options)
+	} else {
+		m = analyzeTrust(commit, rules.trustRules, options)
 	}
 
-	m := analyzeMessage(commit.Message, matchers, options)
+	m.Exemption = exemption
 
 	if IsZeroMatching(m) {
 		return &Matching{}
 	}
 
+	if m.Context == nil {
+		m.Context = map[string]string{"message": commit.Message}
+	}
+
 	m.Context["ID"] = commit.ID().String()
 
 	return m
 }
 
-// analyzeCommits checks if a slice of commits message match expectations
-func analyzeCommits(commits *[]*object.Commit, matchers map[string]string, options Options) *[]*Matching {
-	matchings := []*Matching{}
+// analyzeTrust checks a commit whose message is not checked against trust rules, they
+// can't be waived so Gommit-Skip trailers are ignored
+func analyzeTrust(commit *object.Commit, rules []Rule, options Options) *Matching {
+	matching := Matching{}
+	message := ParseMessage(commit.Message)
+
+	for _, rule := range rules {
+		for _, violation := range rule.Check(message, commit) {
+			if violation.RuleID == "" {
+				violation.RuleID = rule.ID()
+			}
+
+			violation.Severity = severityFor(violation.RuleID, violation.Severity, options)
+			matching.Violations = append(matching.Violations, violation)
+		}
+	}
+
+	if len(matching.Violations) > 0 {
+		matching.Context = map[string]string{"message": commit.Message}
+	}
+
+	return &matching
+}
+
+// analyzeCommits checks if a slice of commits message match expectations, commits
+// are dispatched to a pool of jobs workers, matchings keep the order of commits,
+// range rules then check the whole slice of commits
+func analyzeCommits(commits *[]*object.Commit, rules ruleSet, options Options, jobs int) *[]*Matching {
+	results := make([]*Matching, len(*commits))
+	indexes := make(chan int)
+	var w sync.WaitGroup
+
+	for range max(jobs, 1) {
+		w.Go(func() {
+			for i := range indexes {
+				results[i] = analyzeCommit((*commits)[i], rules, options)
+			}
+		})
+	}
+
+	for i := range *commits {
+		indexes <- i
+	}
+
+	close(indexes)
+	w.Wait()
+
+	for _, rule := range rules.rules {
+		rangeRule, ok := rule.(RangeRule)
+		if !ok {
+			continue
+		}
+
+		for i, violations := range rangeRule.CheckRange(*commits) {
+			if _, commitRules := rules.rulesFor((*commits)[i], options); commitRules == nil {
+				continue
+			}
 
-	for _, commit := range *commits {
-		matching := analyzeCommit(commit, matchers, options)
+			results[i] = addRangeViolations(results[i], (*commits)[i], rangeRule, violations, rules.rules, options)
+		}
+	}
 
+	matchings := []*Matching{}
+
+	for _, matching := range results {
 		if !IsZeroMatching(matching) {
 			matchings = append(matchings, matching)
 		}
@@ -160,27 +371,150 @@ func analyzeCommits(commits *[]*object.Commit, matchers map[string]string, optio
 	return &matchings
 }
 
+// addRangeViolations adds violations found by a range rule to the matching of a commit,
+// violations waived by a Gommit-Skip trailer of the commit are moved to suppressed violations
+func addRangeViolations(matching *Matching, commit *object.Commit, rule RangeRule, violations []Violation, rules []Rule, options Options) *Matching {
+	suppressions, _ := parseSuppressions(ParseMessage(commit.Message), rules)
+
+	for _, violation := range violations {
+		if violation.RuleID == "" {
+			violation.RuleID = rule.ID()
+		}
+
+		violation.Severity = severityFor(violation.RuleID, violation.Severity, options)
+
+		if suppression, ok := findSuppression(violation, suppressions); ok {
+			violation.SuppressionReason = suppression.Reason
+			matching.Suppressed = append(matching.Suppressed, violation)
+
+			continue
+		}
+
+		matching.Violations = append(matching.Violations, violation)
+	}
+
+	if len(matching.Context) == 0 {
+		matching.Context = map[string]string{"message": commit.Message, "ID": commit.ID().String()}
+	}
+
+	return matching
+}
+
+// appendRepositoryRules adds enabled rules which look up data in repository found at path,
+// they run without those lookups when path is not a repository, signature keys are read
+// from files whose relative paths are resolved from path
+func appendRepositoryRules(rules []Rule, repoPath string, options Options) ([]Rule, error) {
+	if options.SignatureKeyring != "" || options.AllowedSignersFile != "" {
+		rule, err := newSignatureRule(repoPath, options.SignatureKeyring, options.AllowedSignersFile)
+		if err != nil {
+			return rules, err
+		}
+
+		rules = append(rules, rule)
+	}
+
+	checkIdentity := len(options.AllowedEmailDomains) > 0 || len(options.PlaceholderNames) > 0 || options.CheckAuthorDate
+
+	if !options.CheckReverts && !checkIdentity {
+		return rules, nil
+	}
+
+	resolver, err := newCommitResolver(repoPath)
+	if err != nil {
+		return rules, err
+	}
+
+	if options.CheckReverts {
+		rules = append(rules, revertRule{requireReason: options.RevertRequireReason, resolver: resolver})
+	}
+
+	if checkIdentity {
+		rules = append(rules, identityRule{
+			domains:      options.AllowedEmailDomains,
+			placeholders: options.PlaceholderNames,
+			checkDates:   options.CheckAuthorDate,
+			now:          time.Now(),
+			resolver:     resolver,
+		})
+	}
+
+	return rules, nil
+}
+
 // MatchMessageQuery triggers regexp matching against a message
 func MatchMessageQuery(query MessageQuery) (*Matching, error) {
-	return analyzeMessage(query.Message, query.Matchers, query.Options), nil
+	matchers, err := compileMatchers(query.Matchers, query.Options)
+	if err != nil {
+		return &Matching{}, err
+	}
+
+	rules := append(buildRules(matchers, query.Options), query.Rules...)
+	message := query.Message
+
+	switch query.Cleanup {
+	case CleanupStrip, CleanupScissors:
+		commentChar, err := fetchCommentChar(query.Path)
+		if err != nil {
+			return &Matching{}, err
+		}
+
+		message = CleanupMessage(message, query.Cleanup, commentChar)
+	case CleanupWhitespace:
+		message = CleanupMessage(message, query.Cleanup, "")
+	}
+
+	if query.Options.CheckBranchIssueKey {
+		branch, err := fetchCurrentBranch(query.Path)
+		if err != nil {
+			return &Matching{}, err
+		}
+
+		rules = append(rules, branchIssueKeyRule{branch: branch, projects: query.Options.IssueProjects})
+	}
+
+	if rules, err = appendRepositoryRules(rules, query.Path, query.Options); err != nil {
+		return &Matching{}, err
+	}
+
+	return analyzeMessage(message, nil, rules, query.Options), nil
 }
 
 // MatchCommitQuery triggers regexp matching against a commit
 func MatchCommitQuery(query CommitQuery) (*Matching, error) {
+	matchers, err := compileMatchers(query.Matchers, query.Options)
+	if err != nil {
+		return &Matching{}, err
+	}
+
 	commit, err := fetchCommit(query.Path, query.ID)
 	if err != nil {
 		return &Matching{}, err
 	}
 
-	return analyzeCommit(commit, query.Matchers, query.Options), nil
+	set, err := buildRuleSet(matchers, query.Options, query.Rules, query.Path)
+	if err != nil {
+		return &Matching{}, err
+	}
+
+	return analyzeCommit(commit, set, query.Options), nil
 }
 
 // MatchRangeQuery triggers regexp matching against a range of commit messages
 func MatchRangeQuery(query RangeQuery) (*[]*Matching, error) {
+	matchers, err := compileMatchers(query.Matchers, query.Options)
+	if err != nil {
+		return &[]*Matching{}, err
+	}
+
 	commits, err := fetchCommits(query.Path, query.From, query.To)
 	if err != nil {
 		return &[]*Matching{}, err
 	}
 
-	return analyzeCommits(commits, query.Matchers, query.Options), nil
+	set, err := buildRuleSet(matchers, query.Options, query.Rules, query.Path)
+	if err != nil {
+		return &[]*Matching{}, err
+	}
+
+	return analyzeCommits(commits, set, query.Options, query.Jobs), nil
 }
@@ -1,8 +1,12 @@
 package gommit
 
 import (
+	"fmt"
+	"os"
 	"os/exec"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/sirupsen/logrus"
@@ -73,7 +77,8 @@ func TestMessageMatchTemplate1(t *testing.T) {
 	msg := "(feat) : Hello world !"
 	temp := "\\((?:feat|test|bug)\\) : .*"
 
-	match := messageMatchTemplate(msg, temp)
+	match, err := mustCompileMatcherSet(map[string]string{"template": temp})[0].match(msg)
+	assert.NoError(t, err)
 	assert.True(t, match, "Message must match template")
 }
 
@@ -85,7 +90,8 @@ func TestMessageMatchTemplate2(t *testing.T) {
 
 	temp := "\\((?:feat|test|bug)\\) : .*?\n(?:\\* .*?\n)+"
 
-	match := messageMatchTemplate(msg, temp)
+	match, err := mustCompileMatcherSet(map[string]string{"template": temp})[0].match(msg)
+	assert.NoError(t, err)
 	assert.True(t, match, "Message must match template")
 }
 
@@ -95,10 +101,42 @@ func TestMessageDoesntMatchTemplate(t *testing.T) {
 
 	temp := "This is a test\n=> an added reaso\n"
 
-	match := messageMatchTemplate(msg, temp)
+	match, err := mustCompileMatcherSet(map[string]string{"template": temp})[0].match(msg)
+	assert.NoError(t, err)
 	assert.False(t, match, "Message must not match template")
 }
 
+func TestMessageMatchTemplateWithATimeout(t *testing.T) {
+	msg := strings.Repeat("a", 50000) + "!"
+	temp := "^(a+)+$"
+
+	set, err := compileMatcherSet(map[string]string{"template": temp}, 10*time.Millisecond, "%s %s")
+	assert.NoError(t, err)
+
+	_, err = set[0].match(msg)
+	assert.Error(t, err, "Must stop a catastrophic backtracking")
+}
+
+func TestMatchMessageQueryWithATimeout(t *testing.T) {
+	q := MessageQuery{
+		Message:  strings.Repeat("a", 50000) + "!",
+		Matchers: map[string]string{"backtracking": "^(a+)+$", "simple": "^b"},
+		Options: Options{
+			DenyMatchers: map[string]string{"backtracking": "(a|aa)+$"},
+			MatchTimeout: 10 * time.Millisecond,
+		},
+	}
+
+	m, err := MatchMessageQuery(q)
+
+	assert.NoError(t, err, "Must return no error")
+	assert.Equal(t, []Violation{
+		{RuleID: RuleMatchTimeout, Message: `matcher "backtracking" timed out after 10ms, check it for catastrophic backtracking`},
+		{RuleID: RuleTemplate, Message: "no template match commit message"},
+		{RuleID: RuleMatchTimeout, Message: `matcher "deny.backtracking" timed out after 10ms, check it for catastrophic backtracking`},
+	}, m.Violations)
+}
+
 func TestMatchRangeQuery(t *testing.T) {
 	err := exec.Command("../features/repo.sh").Run()
 	if err != nil {
@@ -146,8 +184,8 @@ func TestMatchRangeQueryrWithAMessageErrorCommit(t *testing.T) {
 	assert.NoError(t, err, "Must return no errors")
 	assert.Len(t, *m, 2, "Must return two items")
 	assert.Equal(t, "feat(file8) : new file 8\n\ncreate a new file 8\n", (*m)[0].Context["message"], "Must contains commit message")
-	assert.EqualError(t, (*m)[0].MessageError, "no template match commit message", "Must contains commit message error")
-	assert.NoError(t, (*m)[0].SummaryError, "Must not contains error")
+	assert.Equal(t, []string{"no template match commit message"}, violationMessages((*m)[0].RuleViolations(RuleTemplate)), "Must contains commit message error")
+	assert.Empty(t, (*m)[0].RuleViolations(RuleSummaryLength), "Must not contains error")
 }
 
 func TestMatchRangeQueryWithASummaryErrorCommit(t *testing.T) {
@@ -175,8 +213,8 @@ func TestMatchRangeQueryWithASummaryErrorCommit(t *testing.T) {
 	assert.NoError(t, err, "Must return no errors")
 	assert.Len(t, *m, 1, "Must return one item")
 	assert.Equal(t, "A very long summary commit greater than minimum length 50\n", (*m)[0].Context["message"], "Must contains commit message")
-	assert.NoError(t, (*m)[0].MessageError, "Must not contains error")
-	assert.EqualError(t, (*m)[0].SummaryError, "commit summary length is greater than 50 characters", "Must contains summary message error")
+	assert.Empty(t, (*m)[0].RuleViolations(RuleTemplate), "Must not contains error")
+	assert.Equal(t, []string{"commit summary length is 57 characters, greater than 50 characters"}, violationMessages((*m)[0].RuleViolations(RuleSummaryLength)), "Must contains summary message error")
 }
 
 func TestMatchRangeWithAMessageErrorCommitWithoutMergeCommit(t *testing.T) {
@@ -206,8 +244,8 @@ func TestMatchRangeWithAMessageErrorCommitWithoutMergeCommit(t *testing.T) {
 	for i := range 7 {
 		assert.NotContains(t, (*m)[i].Context["message"], "Merge")
 	}
-	assert.EqualError(t, (*m)[0].MessageError, "no template match commit message", "Must contains commit message error")
-	assert.NoError(t, (*m)[0].SummaryError, "Must not contains error")
+	assert.Equal(t, []string{"no template match commit message"}, violationMessages((*m)[0].RuleViolations(RuleTemplate)), "Must contains commit message error")
+	assert.Empty(t, (*m)[0].RuleViolations(RuleSummaryLength), "Must not contains error")
 }
 
 func TestMatchRangeQueryWithAMessageErrorCommitWithMergeCommits(t *testing.T) {
@@ -234,8 +272,8 @@ func TestMatchRangeQueryWithAMessageErrorCommitWithMergeCommits(t *testing.T) {
 
 	assert.NoError(t, err, "Must return no errors")
 	assert.Len(t, *m, 9, "Must return two itesm")
-	assert.EqualError(t, (*m)[0].MessageError, "no template match commit message", "Must contains commit message error")
-	assert.NoError(t, (*m)[0].SummaryError, "Must not contains error")
+	assert.Equal(t, []string{"no template match commit message"}, violationMessages((*m)[0].RuleViolations(RuleTemplate)), "Must contains commit message error")
+	assert.Empty(t, (*m)[0].RuleViolations(RuleSummaryLength), "Must not contains error")
 }
 
 func TestMatchRangeWithAnUnexistingCommitRange(t *testing.T) {
@@ -326,8 +364,8 @@ func TestMatchMessageQueryWithAMessageThatDoesntMatchTemplate(t *testing.T) {
 	m, err := MatchMessageQuery(q)
 
 	assert.NoError(t, err, "Must return no error")
-	assert.EqualError(t, m.MessageError, "no template match commit message", "Must return a template message error")
-	assert.NoError(t, m.SummaryError, "Must return no summary error")
+	assert.Equal(t, []string{"no template match commit message"}, violationMessages(m.RuleViolations(RuleTemplate)), "Must return a template message error")
+	assert.Empty(t, m.RuleViolations(RuleSummaryLength), "Must return no summary error")
 	assert.Equal(t, "update(file) :", m.Context["message"], "Must contains original message")
 }
 
@@ -350,8 +388,8 @@ func TestMatchMessageQueryWithAMessageThatDoesntFitSummaryLength(t *testing.T) {
 	m, err := MatchMessageQuery(q)
 
 	assert.NoError(t, err, "Must return no error")
-	assert.NoError(t, m.MessageError, "Must return no template message error")
-	assert.EqualError(t, m.SummaryError, "commit summary length is greater than 50 characters", "Must return a template message error")
+	assert.Empty(t, m.RuleViolations(RuleTemplate), "Must return no template message error")
+	assert.Equal(t, []string{"commit summary length is 84 characters, greater than 50 characters"}, violationMessages(m.RuleViolations(RuleSummaryLength)), "Must return a template message error")
 	assert.Equal(t, "update(file) : test test test test test test test test test test test test test test", m.Context["message"], "Must contains original message")
 }
 
@@ -416,8 +454,8 @@ func TestMatchCommitQueryWithCommitMessageThatDoesntMatchTemplate(t *testing.T)
 	assert.NoError(t, err, "Must return no error")
 	assert.Equal(t, "feat(file8) : new file 8\n\ncreate a new file 8\n", m.Context["message"], "Must contains commit message")
 	assert.Equal(t, string(ID[:len(ID)-1]), m.Context["ID"], "Must contains commit id")
-	assert.EqualError(t, m.MessageError, "no template match commit message", "Must contains commit message error")
-	assert.NoError(t, m.SummaryError, "Must not contains error")
+	assert.Equal(t, []string{"no template match commit message"}, violationMessages(m.RuleViolations(RuleTemplate)), "Must contains commit message error")
+	assert.Empty(t, m.RuleViolations(RuleSummaryLength), "Must not contains error")
 }
 
 func TestMatchCommitQueryWithWrongRepository(t *testing.T) {
@@ -473,15 +511,18 @@ func TestMatchCommitQueryWithAnUnexistingCommit(t *testing.T) {
 }
 
 func TestIsValidSummaryLengthWithCorrectSize(t *testing.T) {
-	assert.True(t, isValidSummaryLength(50, "test"))
-	assert.True(t, isValidSummaryLength(50, "a sequence which is 50 size long abcdefghijklmnopq"), "Must have a length which is exactly 50 characters")
-	assert.True(t, isValidSummaryLength(72, "test"))
-	assert.True(t, isValidSummaryLength(72, "a sequence which is 72 size long abcdefghijklmnopqrstuvwxyz abcdefghijkl"), "Must have a length which is exactly 72 characters")
+	assert.True(t, isValidSummaryLength(50, "test", LengthModeRunes))
+	assert.True(t, isValidSummaryLength(50, "a sequence which is 50 size long abcdefghijklmnopq", LengthModeRunes), "Must have a length which is exactly 50 characters")
+	assert.True(t, isValidSummaryLength(72, "test", LengthModeRunes))
+	assert.True(t, isValidSummaryLength(72, "a sequence which is 72 size long abcdefghijklmnopqrstuvwxyz abcdefghijkl", LengthModeRunes), "Must have a length which is exactly 72 characters")
+	assert.True(t, isValidSummaryLength(32, "fix(api) : gère les réponses éàü", LengthModeRunes), "Must count characters and not bytes")
+	assert.True(t, isValidSummaryLength(20, "fix(api) : 応答を処理する", LengthModeRunes), "Must count characters and not bytes")
 }
 
 func TestIsValidSummaryLengthWithInCorrectSize(t *testing.T) {
-	assert.False(t, isValidSummaryLength(50, "a sequence which is 51 size long abcdefghijklmnopqr"))
-	assert.False(t, isValidSummaryLength(72, "a sequence which is 73 size long abcdefghijklmnopqrstuvwxyz abcdefghijklm"))
+	assert.False(t, isValidSummaryLength(50, "a sequence which is 51 size long abcdefghijklmnopqr", LengthModeRunes))
+	assert.False(t, isValidSummaryLength(72, "a sequence which is 73 size long abcdefghijklmnopqrstuvwxyz abcdefghijklm", LengthModeRunes))
+	assert.False(t, isValidSummaryLength(20, "fix(api) : 応答を処理する", LengthModeDisplayWidth), "Must count wide characters as two columns")
 }
 
 func TestIsMergeCommitWithANonMergeCommit(t *testing.T) {
@@ -513,3 +554,389 @@ func TestIsMergeCommitWithAMergeCommit(t *testing.T) {
 
 	assert.True(t, isMergeCommit((*commits)[0]), "Must return false with non merge commit")
 }
+
+func TestIsMergeCommitWithAnOctopusMergeCommit(t *testing.T) {
+	for _, filename := range []string{"../features/repo.sh", "../features/octopus-merge-commit.sh"} {
+		err := exec.Command(filename).Run()
+		if err != nil {
+			logrus.Fatal(err)
+		}
+	}
+
+	commits, err := fetchCommits("testing-repository", "test~1", "test")
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	assert.Equal(t, 3, (*commits)[0].NumParents())
+	assert.True(t, isMergeCommit((*commits)[0]), "Must return true with an octopus merge commit")
+}
+
+func TestMatchRangeQueryWithMergeMatchers(t *testing.T) {
+	for _, filename := range []string{"../features/repo.sh", "../features/octopus-merge-commit.sh"} {
+		err := exec.Command(filename).Run()
+		if err != nil {
+			logrus.Fatal(err)
+		}
+	}
+
+	type scenario struct {
+		name          string
+		mergeMatchers map[string]string
+		violations    []Violation
+	}
+
+	scenarios := []scenario{
+		{
+			"No merge matchers",
+			map[string]string{},
+			[]Violation{{RuleID: RuleTemplate, Message: "no template match commit message"}},
+		},
+		{
+			"Merge matchers matching",
+			map[string]string{"branch": "^Merge branch(?:es)? '.+?'(?: and '.+?')* into .+"},
+			nil,
+		},
+		{
+			"Merge matchers not matching",
+			map[string]string{"pull-request": "^Merge pull request #\\d+"},
+			[]Violation{{RuleID: RuleTemplate, Message: "no template match commit message"}},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			q := RangeQuery{
+				Path:     "testing-repository/",
+				From:     "test~1",
+				To:       "test",
+				Matchers: map[string]string{"simple": "^feat\\(.*?\\) : .*"},
+				Options:  Options{MergeMatchers: s.mergeMatchers},
+			}
+
+			m, err := MatchRangeQuery(q)
+
+			assert.NoError(t, err, "Must return no errors")
+
+			if s.violations == nil {
+				assert.Empty(t, *m)
+
+				return
+			}
+
+			assert.Len(t, *m, 1)
+			assert.Equal(t, s.violations, (*m)[0].Violations)
+			assert.Equal(t, "Merge branches 'octopus1' and 'octopus2' into test\n", (*m)[0].Context["message"])
+		})
+	}
+}
+
+func TestMatchMessageQueryWithConventionalCommits(t *testing.T) {
+	q := MessageQuery{
+		Message: "feat(file) :add a feature",
+		Options: Options{
+			ConventionalCommits: true,
+		},
+	}
+
+	m, err := MatchMessageQuery(q)
+
+	assert.NoError(t, err, "Must return no error")
+	assert.Empty(t, m.RuleViolations(RuleTemplate), "Must return no template message error when no matchers are defined")
+	assert.Equal(t, []Violation{
+		{RuleID: RuleConventionalCommits, Message: `type or scope must be followed by ": "`, Span: newSpan(1, 11, 11), Suggestion: ": "},
+	}, m.RuleViolations(RuleConventionalCommits), "Must return conventional commits violations")
+	assert.Equal(t, "feat(file) :add a feature", m.Context["message"], "Must contains original message")
+
+	q.Message = "feat(file): add a feature"
+
+	m, err = MatchMessageQuery(q)
+
+	assert.NoError(t, err, "Must return no error")
+	assert.True(t, IsZeroMatching(m), "Must return an empty matching struct")
+}
+
+func TestMatchMessageQueryWithAllowedTypesAndMatchersWithoutNamedGroups(t *testing.T) {
+	q := MessageQuery{
+		Message:  "fet(frontned) : fix",
+		Matchers: map[string]string{"grouped": "(?<type>\\w+)\\(.*?\\) : .*", "simple": "\\w+\\(.*?\\) : .*"},
+		Options:  Options{Types: []string{"feat", "fix"}},
+	}
+
+	_, err := MatchMessageQuery(q)
+
+	assert.EqualError(t, err, `regexp "\w+\(.*?\) : .*" identified by "simple" must define a named group "type" as allowed types are defined, e.g. (?<type>...)`)
+
+	q.Options = Options{Scopes: []string{"backend", "frontend"}}
+
+	_, err = MatchMessageQuery(q)
+
+	assert.EqualError(t, err, `regexp "(?<type>\w+)\(.*?\) : .*" identified by "grouped" must define a named group "scope" as allowed scopes are defined, e.g. (?<scope>...)`)
+
+	q.Options = Options{ConventionalCommits: true, Types: []string{"feat", "fix"}}
+
+	_, err = MatchMessageQuery(q)
+
+	assert.NoError(t, err, "Must not use matchers groups with conventional commits")
+}
+
+func TestMatchMessageQueryWithAllowedTypesAndScopes(t *testing.T) {
+	type scenario struct {
+		name     string
+		query    MessageQuery
+		typeErr  string
+		scopeErr string
+	}
+
+	scenarios := []scenario{
+		{
+			"Named groups from matchers",
+			MessageQuery{
+				Message:  "fet(frontned) : fix",
+				Matchers: map[string]string{"simple": "(?<type>\\w+)\\((?<scope>.*?)\\) : .*"},
+				Options:  Options{Types: []string{"feat", "fix"}, Scopes: []string{"backend", "frontend"}},
+			},
+			`unknown type "fet", did you mean "feat"?`,
+			`unknown scope "frontned", did you mean "frontend"?`,
+		},
+		{
+			"Conventional commits parser",
+			MessageQuery{
+				Message: "docs(database): fix",
+				Options: Options{ConventionalCommits: true, Types: []string{"feat", "fix"}, Scopes: []string{"backend", "frontend"}},
+			},
+			`unknown type "docs", expected one of feat, fix`,
+			`unknown scope "database", expected one of backend, frontend`,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			m, err := MatchMessageQuery(s.query)
+
+			assert.NoError(t, err)
+			assert.Equal(t, []string{s.typeErr}, violationMessages(m.RuleViolations(RuleType)))
+			assert.Equal(t, []string{s.scopeErr}, violationMessages(m.RuleViolations(RuleScope)))
+		})
+	}
+}
+
+func TestHasValidSeparator(t *testing.T) {
+	assert.True(t, hasValidSeparator(ParseMessage("summary\n")))
+	assert.True(t, hasValidSeparator(ParseMessage("summary\n\nbody\n")))
+	assert.True(t, hasValidSeparator(ParseMessage("summary\n\nSigned-off-by: John Doe <john@example.com>\n")))
+	assert.False(t, hasValidSeparator(ParseMessage("summary\nbody\n")))
+	assert.False(t, hasValidSeparator(ParseMessage("summary\n\n\nbody\n")))
+}
+
+func TestFindLongBodyLines(t *testing.T) {
+	message := "summary\n\n"
+	message += "a short line\n"
+	message += "a line which is longer than 30 characters\n"
+	message += "see https://example.com/a/very/long/url/which/can/not/be/wrapped\n"
+	message += "\n"
+	message += "    an indented code block line longer than 30 characters\n"
+	message += "\tan indented code block line longer than 30 characters\n"
+	message += "another line which is longer than 30 characters\n"
+	message += "\n"
+	message += "Signed-off-by: John Doe <a-very-long-email-address@example.com>\n"
+
+	assert.Equal(t, []int{4, 9}, findLongBodyLines(30, ParseMessage(message)))
+	assert.Empty(t, findLongBodyLines(72, ParseMessage(message)))
+
+	message = "summary\n\n" + strings.Repeat("é", 49) + "\n"
+
+	assert.Empty(t, findLongBodyLines(72, ParseMessage(message)), "Must count characters, not bytes")
+	assert.Equal(t, []int{3}, findLongBodyLines(48, ParseMessage(message)))
+}
+
+func TestMatchMessageQueryWithBodyErrors(t *testing.T) {
+	q := MessageQuery{
+		Message:  "update(file) : fix\ntest test test test test test test test test test test test test test test test\n",
+		Matchers: map[string]string{"simple": "(?:update|feat)\\(.*?\\) : .*"},
+		Options: Options{
+			CheckBodyLineLength:   true,
+			CheckSummarySeparator: true,
+			BodyLineLength:        72,
+		},
+	}
+
+	m, err := MatchMessageQuery(q)
+
+	assert.NoError(t, err, "Must return no error")
+	assert.Empty(t, m.RuleViolations(RuleTemplate), "Must return no template message error")
+	assert.Equal(t, []string{"commit summary must be followed by exactly one blank line"}, violationMessages(m.RuleViolations(RuleSummarySeparator)))
+	assert.Equal(t, []Violation{
+		{RuleID: RuleBodyLineLength, Message: "commit body line 2 length is greater than 72 characters", Span: newSpan(2, 73, 80)},
+	}, m.RuleViolations(RuleBodyLineLength))
+	assert.Len(t, m.Violations, 2)
+}
+
+func TestMatchRangeQueryWithSignedOffByCommits(t *testing.T) {
+	for _, filename := range []string{"../features/repo.sh", "../features/signed-off-commits.sh"} {
+		err := exec.Command(filename).Run()
+		if err != nil {
+			logrus.Fatal(err)
+		}
+	}
+
+	q := RangeQuery{
+		Path:     "testing-repository/",
+		From:     "test~3",
+		To:       "test",
+		Matchers: map[string]string{"simple": "(?:update|feat)\\(.*?\\) : .*"},
+		Options: Options{
+			CheckSignedOffBy: true,
+		},
+	}
+
+	m, err := MatchRangeQuery(q)
+
+	assert.NoError(t, err, "Must return no errors")
+	assert.Len(t, *m, 2, "Must return two items")
+	assert.Equal(t, "feat(file11) : new file 11\n\nSigned-off-by: John Doe <john@example.com>\n", (*m)[0].Context["message"])
+	assert.Len(t, (*m)[0].RuleViolations(RuleTrailers), 1)
+	assert.Regexp(t, `no "Signed-off-by" trailer matches commit author ".+"`, (*m)[0].RuleViolations(RuleTrailers)[0].Message)
+	assert.Equal(t, "feat(file8) : new file 8\n\ncreate a new file 8\n", (*m)[1].Context["message"])
+	assert.Equal(t, []string{`required trailer "Signed-off-by" is missing`}, violationMessages((*m)[1].RuleViolations(RuleTrailers)))
+}
+
+func TestMatchMessageQueryWithDeniedContent(t *testing.T) {
+	q := MessageQuery{
+		Message:  "update(file) : fix DO NOT MERGE",
+		Matchers: map[string]string{"simple": "(?:update|feat)\\(.*?\\) : .*"},
+		Options: Options{
+			DenyMatchers: map[string]string{"do_not_merge": "DO NOT MERGE"},
+		},
+	}
+
+	m, err := MatchMessageQuery(q)
+
+	assert.NoError(t, err, "Must return no error")
+	assert.Empty(t, m.RuleViolations(RuleTemplate), "Must return no template message error, deny matchers are checked even if a matcher passed")
+	assert.Equal(t, []Violation{
+		{RuleID: "deny.do_not_merge", Message: `deny rule "do_not_merge" matched "DO NOT MERGE"`, Span: newSpan(1, 20, 32)},
+	}, m.RuleViolations(RuleDeny))
+}
+
+func TestMatchMessageQueryWithMissingIssueKey(t *testing.T) {
+	q := MessageQuery{
+		Message:  "update(file) : fix",
+		Matchers: map[string]string{"simple": "(?<type>update|feat)\\(.*?\\) : .*"},
+		Options: Options{
+			IssueProjects:    []string{"PROJ"},
+			IssueExemptTypes: []string{"feat"},
+		},
+	}
+
+	m, err := MatchMessageQuery(q)
+
+	assert.NoError(t, err, "Must return no error")
+	assert.Equal(t, []string{"missing issue key, expected one of PROJ"}, violationMessages(m.RuleViolations(RuleIssueKey)))
+
+	q.Message = "feat(file) : fix"
+
+	m, err = MatchMessageQuery(q)
+
+	assert.NoError(t, err, "Must return no error")
+	assert.True(t, IsZeroMatching(m), "Must not check exempted types")
+}
+
+func TestMatchMessageQueryWithBranchIssueKey(t *testing.T) {
+	err := exec.Command("../features/repo.sh").Run()
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	cmd := exec.Command("git", "checkout", "--quiet", "-b", "feature/PROJ-123-login")
+	cmd.Dir = "testing-repository"
+	assert.NoError(t, cmd.Run())
+
+	q := MessageQuery{
+		Path:     "testing-repository/",
+		Message:  "update(file) : PROJ-456 fix",
+		Matchers: map[string]string{"simple": "(?:update|feat)\\(.*?\\) : .*"},
+		Options: Options{
+			IssueProjects:       []string{"PROJ"},
+			CheckBranchIssueKey: true,
+		},
+	}
+
+	m, err := MatchMessageQuery(q)
+
+	assert.NoError(t, err, "Must return no error")
+	assert.Equal(t, []string{`commit message references "PROJ-456" but branch "feature/PROJ-123-login" references "PROJ-123"`}, violationMessages(m.RuleViolations(RuleBranchIssueKey)))
+	assert.Equal(t, "update(file) : PROJ-456 fix", m.Context["message"], "Must contains original message")
+
+	q.Message = "update(file) : PROJ-123 fix"
+
+	m, err = MatchMessageQuery(q)
+
+	assert.NoError(t, err, "Must return no error")
+	assert.True(t, IsZeroMatching(m), "Must return an empty matching struct")
+
+	assert.NoError(t, os.MkdirAll("testing-repository/subdirectory", 0o755))
+
+	q.Path = "testing-repository/subdirectory"
+	q.Message = "update(file) : PROJ-456 fix"
+
+	m, err = MatchMessageQuery(q)
+
+	assert.NoError(t, err, "Must return no error")
+	assert.Len(t, m.RuleViolations(RuleBranchIssueKey), 1, "Must look up branch from a repository subdirectory")
+
+	q.Path = "testtesttest"
+
+	_, err = MatchMessageQuery(q)
+
+	assert.EqualError(t, err, "repository does not exist")
+}
+
+func TestAnalyzeCommitsWithJobs(t *testing.T) {
+	commits := syntheticHistory(500)
+	matchers, err := compileMatchers(map[string]string{"simple": "(?<type>feat)\\(file\\d*[02468]\\) : .*"}, Options{})
+	assert.NoError(t, err)
+
+	rules := ruleSet{rules: buildRules(matchers, Options{})}
+	expected := analyzeCommits(commits, rules, Options{}, 1)
+
+	assert.Len(t, *expected, 250)
+
+	for _, jobs := range []int{0, 4, 16} {
+		matchings := analyzeCommits(commits, rules, Options{}, jobs)
+
+		assert.Equal(t, expected, matchings, "Must keep commits order")
+	}
+}
+
+func BenchmarkAnalyzeCommitsWithJobs(b *testing.B) {
+	commits := syntheticHistory(20000)
+
+	for _, jobs := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("%d jobs", jobs), func(b *testing.B) {
+			for b.Loop() {
+				matchers, err := compileMatchers(benchmarkMatchers, benchmarkOptions)
+				if err != nil {
+					b.Fatal(err)
+				}
+
+				analyzeCommits(commits, ruleSet{rules: buildRules(matchers, benchmarkOptions)}, benchmarkOptions, jobs)
+			}
+		})
+	}
+}
+
+func BenchmarkDuplicateSummaryRule(b *testing.B) {
+	for _, s := range []struct {
+		size      int
+		threshold float64
+	}{{20000, 0}, {2000, 0.95}} {
+		commits := *syntheticHistory(s.size)
+
+		b.Run(fmt.Sprintf("%d commits with threshold %.2f", s.size, s.threshold), func(b *testing.B) {
+			for b.Loop() {
+				duplicateSummaryRule{threshold: s.threshold}.CheckRange(commits)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package gommit
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// Rule IDs of identity checks, they're part of the identity rule
+const (
+	ruleIdentityEmail = RuleIdentity + ".email"
+	ruleIdentityName  = RuleIdentity + ".name"
+	ruleIdentityDate  = RuleIdentity + ".date"
+)
+
+// dateLayout is used to display commit dates
+const dateLayout = "2006-01-02 15:04:05 -0700"
+
+// emailDomain returns the lower cased domain of an email address
+func emailDomain(email string) string {
+	i := strings.LastIndex(email, "@")
+
+	return strings.ToLower(email[i+1:])
+}
+
+// isAllowedDomain returns true if the domain of an email address is one of the allowed domains,
+// a domain written "*.example.com" allows every subdomain of example.com
+func isAllowedDomain(email string, domains []string) bool {
+	domain := emailDomain(email)
+
+	for _, allowed := range domains {
+		allowed = strings.ToLower(allowed)
+
+		if suffix, ok := strings.CutPrefix(allowed, "*"); ok && strings.HasSuffix(domain, suffix) {
+			return true
+		}
+
+		if domain == allowed {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isPlaceholderName returns true if a name is one of placeholders, case is ignored
+func isPlaceholderName(name string, placeholders []string) bool {
+	return slices.ContainsFunc(placeholders, func(placeholder string) bool {
+		return strings.EqualFold(strings.TrimSpace(name), placeholder)
+	})
+}
+
+// identityRule ensures author and committer of a commit are properly configured, email
+// addresses must belong to allowed domains, names must not be placeholders and author date
+// must be neither in the future nor before the first commit of repository, messages
+// checked on their own are not checked
+type identityRule struct {
+	domains      []string
+	placeholders []string
+	checkDates   bool
+	now          time.Time
+	resolver     *commitResolver
+}
+
+func (r identityRule) ID() string {
+	return RuleIdentity
+}
+
+func (r identityRule) Check(message *Message, commit *object.Commit) []Violation {
+	if commit == nil {
+		return nil
+	}
+
+	violations := []Violation{}
+
+	for _, s := range []struct {
+		role      string
+		signature object.Signature
+	}{{"author", commit.Author}, {"committer", commit.Committer}} {
+		if len(r.domains) > 0 && !isAllowedDomain(s.signature.Email, r.domains) {
+			violations = append(violations, Violation{
+				RuleID:  ruleIdentityEmail,
+				Message: fmt.Sprintf(`%s email "%s" doesn't belong to an allowed domain, expected one of %s`, s.role, s.signature.Email, strings.Join(r.domains, ", ")),
+			})
+		}
+
+		if isPlaceholderName(s.signature.Name, r.placeholders) {
+			violations = append(violations, Violation{
+				RuleID:  ruleIdentityName,
+				Message: fmt.Sprintf(`%s name "%s" is a placeholder, configure user.name in git`, s.role, s.signature.Name),
+			})
+		}
+	}
+
+	if r.checkDates {
+		violations = append(violations, r.checkAuthorDate(commit.Author.When)...)
+	}
+
+	return violations
+}
+
+// checkAuthorDate ensures author date is neither in the future nor before the first commit
+// of repository, the first commit is not looked up when no repository is available
+func (r identityRule) checkAuthorDate(date time.Time) []Violation {
+	if date.After(r.now) {
+		return []Violation{{
+			RuleID:  ruleIdentityDate,
+			Message: fmt.Sprintf("author date %s is in the future", date.Format(dateLayout)),
+		}}
+	}
+
+	if r.resolver == nil {
+		return nil
+	}
+
+	first, err := r.resolver.firstDate()
+	if err != nil {
+		return []Violation{{
+			RuleID:  ruleIdentityDate,
+			Message: fmt.Sprintf("first commit of repository can't be looked up : %s", err),
+		}}
+	}
+
+	if date.Before(first) {
+		return []Violation{{
+			RuleID:  ruleIdentityDate,
+			Message: fmt.Sprintf("author date %s is before the first commit of repository, made on %s", date.Format(dateLayout), first.Format(dateLayout)),
+		}}
+	}
+
+	return nil
+}
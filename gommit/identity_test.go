@@ -0,0 +1,100 @@
+package gommit
+
+import (
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsAllowedDomain(t *testing.T) {
+	type scenario struct {
+		email   string
+		domains []string
+		allowed bool
+	}
+
+	scenarios := []scenario{
+		{"john@example.com", []string{"example.com"}, true},
+		{"john@EXAMPLE.com", []string{"example.com"}, true},
+		{"john@mail.example.com", []string{"example.com"}, false},
+		{"john@mail.example.com", []string{"*.example.com"}, true},
+		{"john@badexample.com", []string{"*.example.com"}, false},
+		{"john@localhost", []string{"example.com", "example.org"}, false},
+		{"john", []string{"example.com"}, false},
+	}
+
+	for _, s := range scenarios {
+		assert.Equal(t, s.allowed, isAllowedDomain(s.email, s.domains), "Must check domain of %s", s.email)
+	}
+}
+
+func TestIdentityRule(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	rule := identityRule{
+		domains:      []string{"example.com"},
+		placeholders: []string{"root", "ubuntu"},
+		checkDates:   true,
+		now:          now,
+	}
+
+	commit := &object.Commit{
+		Author:    object.Signature{Name: "John Doe", Email: "john@example.com", When: now.Add(-time.Hour)},
+		Committer: object.Signature{Name: "John Doe", Email: "john@example.com", When: now.Add(-time.Hour)},
+	}
+
+	assert.Empty(t, rule.Check(ParseMessage("feat : a feature"), commit))
+	assert.Empty(t, rule.Check(ParseMessage("feat : a feature"), nil), "Must not check a message on its own")
+
+	commit = &object.Commit{
+		Author:    object.Signature{Name: "Ubuntu", Email: "ubuntu@ip-10-0-0-1", When: now.Add(time.Hour)},
+		Committer: object.Signature{Name: "root", Email: "root@example.com", When: now},
+	}
+
+	assert.Equal(t, []Violation{
+		{RuleID: "identity.email", Message: `author email "ubuntu@ip-10-0-0-1" doesn't belong to an allowed domain, expected one of example.com`},
+		{RuleID: "identity.name", Message: `author name "Ubuntu" is a placeholder, configure user.name in git`},
+		{RuleID: "identity.name", Message: `committer name "root" is a placeholder, configure user.name in git`},
+		{RuleID: "identity.date", Message: "author date 2026-01-01 01:00:00 +0000 is in the future"},
+	}, rule.Check(ParseMessage("feat : a feature"), commit))
+}
+
+func TestMatchRangeQueryWithIdentityChecks(t *testing.T) {
+	for _, filename := range []string{"../features/repo.sh", "../features/identity-commits.sh"} {
+		err := exec.Command(filename).Run()
+		if err != nil {
+			logrus.Fatal(err)
+		}
+	}
+
+	q := RangeQuery{
+		Path:     "testing-repository/",
+		From:     "test~3",
+		To:       "test",
+		Matchers: map[string]string{"simple": "^feat\\(.*?\\) : .*"},
+		Options: Options{
+			AllowedEmailDomains: []string{"example.com"},
+			PlaceholderNames:    []string{"root"},
+			CheckAuthorDate:     true,
+			Severities:          map[string]Severity{"identity.name": SeverityWarning},
+		},
+	}
+
+	m, err := MatchRangeQuery(q)
+
+	assert.NoError(t, err, "Must return no errors")
+	assert.Len(t, *m, 3)
+	assert.Len(t, (*m)[0].Violations, 1)
+	assert.Equal(t, "identity.date", (*m)[0].Violations[0].RuleID)
+	assert.Regexp(t, `^author date 2000-01-01 00:00:00 \+0000 is before the first commit of repository, made on `, (*m)[0].Violations[0].Message)
+	assert.Equal(t, []Violation{
+		{RuleID: "identity.date", Message: "author date 2099-01-01 00:00:00 +0000 is in the future"},
+	}, (*m)[1].Violations)
+	assert.Equal(t, []Violation{
+		{RuleID: "identity.email", Message: `author email "root@localhost" doesn't belong to an allowed domain, expected one of example.com`},
+		{RuleID: "identity.name", Severity: SeverityWarning, Message: `author name "root" is a placeholder, configure user.name in git`},
+	}, (*m)[2].Violations)
+}
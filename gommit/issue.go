@@ -0,0 +1,78 @@
+package gommit
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// refsTrailer is the trailer key used to reference issues
+const refsTrailer = "Refs"
+
+// issueKeyRegexp builds a regexp matching issue keys like PROJ-123 for given projects
+func issueKeyRegexp(projects []string) *regexp.Regexp {
+	quoted := []string{}
+
+	for _, project := range projects {
+		quoted = append(quoted, regexp.QuoteMeta(project))
+	}
+
+	return regexp.MustCompile(`\b(?:` + strings.Join(quoted, "|") + `)-[0-9]+\b`)
+}
+
+// findIssueKeys returns issue keys referenced in summary and in Refs trailers of a message
+func findIssueKeys(message *Message, projects []string) []string {
+	r := issueKeyRegexp(projects)
+	keys := r.FindAllString(message.Summary, -1)
+
+	for _, value := range message.TrailerValues(refsTrailer) {
+		keys = append(keys, r.FindAllString(value, -1)...)
+	}
+
+	return keys
+}
+
+// checkIssueKey ensures a message references an issue key from one of the given projects,
+// messages whose type is exempted are not checked
+func checkIssueKey(message *Message, commitType string, projects []string, exemptTypes []string) *Violation {
+	if len(projects) == 0 || slices.Contains(exemptTypes, commitType) {
+		return nil
+	}
+
+	if len(findIssueKeys(message, projects)) == 0 {
+		return &Violation{Message: fmt.Sprintf("missing issue key, expected one of %s", strings.Join(projects, ", ")), Span: message.lineSpan(1)}
+	}
+
+	return nil
+}
+
+// checkBranchIssueKey ensures a message references the issue key found in a branch name,
+// nothing is checked if the branch doesn't contain any issue key
+func checkBranchIssueKey(message *Message, branch string, projects []string) *Violation {
+	if len(projects) == 0 {
+		return nil
+	}
+
+	branchKey := issueKeyRegexp(projects).FindString(branch)
+
+	if branchKey == "" {
+		return nil
+	}
+
+	keys := findIssueKeys(message, projects)
+
+	if slices.Contains(keys, branchKey) {
+		return nil
+	}
+
+	if len(keys) > 0 {
+		return &Violation{
+			Message:    fmt.Sprintf(`commit message references "%s" but branch "%s" references "%s"`, strings.Join(keys, ", "), branch, branchKey),
+			Span:       message.substringSpan(1, keys[0]),
+			Suggestion: branchKey,
+		}
+	}
+
+	return &Violation{Message: fmt.Sprintf(`issue key "%s" from branch "%s" is not referenced in commit message`, branchKey, branch), Span: message.lineSpan(1), Suggestion: branchKey}
+}
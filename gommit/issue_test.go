@@ -0,0 +1,45 @@
+package gommit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindIssueKeys(t *testing.T) {
+	projects := []string{"PROJ", "OPS"}
+
+	assert.Equal(t, []string{"PROJ-123"}, findIssueKeys(ParseMessage("feat(file) : PROJ-123 a feature\n"), projects))
+	assert.Equal(t, []string{"OPS-1", "PROJ-2"}, findIssueKeys(ParseMessage("feat(file) : a feature\n\nbody mentioning PROJ-4\n\nRefs: OPS-1, PROJ-2\n"), projects))
+	assert.Empty(t, findIssueKeys(ParseMessage("feat(file) : MYPROJ-123 a feature\n"), projects))
+	assert.Empty(t, findIssueKeys(ParseMessage("feat(file) : PROJ-abc a feature\n"), projects))
+}
+
+func TestCheckIssueKey(t *testing.T) {
+	projects := []string{"PROJ", "OPS"}
+	exemptTypes := []string{"chore"}
+
+	assert.Nil(t, checkIssueKey(ParseMessage("feat(file) : PROJ-123 a feature\n"), "feat", projects, exemptTypes))
+	assert.Nil(t, checkIssueKey(ParseMessage("chore(deps) : bump a dependency\n"), "chore", projects, exemptTypes), "Must not check exempted types")
+	assert.Nil(t, checkIssueKey(ParseMessage("feat(file) : a feature\n"), "feat", []string{}, exemptTypes), "Must not check anything when no project is defined")
+	assert.Equal(t, &Violation{Message: "missing issue key, expected one of PROJ, OPS", Span: newSpan(1, 1, 23)}, checkIssueKey(ParseMessage("feat(file) : a feature\n"), "feat", projects, exemptTypes))
+}
+
+func TestCheckBranchIssueKey(t *testing.T) {
+	projects := []string{"PROJ", "OPS"}
+
+	assert.Nil(t, checkBranchIssueKey(ParseMessage("feat(file) : PROJ-123 a feature\n"), "feature/PROJ-123-login", projects))
+	assert.Nil(t, checkBranchIssueKey(ParseMessage("feat(file) : a feature\n\nRefs: OPS-1, PROJ-123\n"), "feature/PROJ-123-login", projects))
+	assert.Nil(t, checkBranchIssueKey(ParseMessage("feat(file) : a feature\n"), "feature/login", projects), "Must not check anything when branch has no issue key")
+	assert.Nil(t, checkBranchIssueKey(ParseMessage("feat(file) : a feature\n"), "feature/PROJ-123-login", []string{}), "Must not check anything when no project is defined")
+	assert.Equal(t, &Violation{
+		Message:    `commit message references "PROJ-456" but branch "feature/PROJ-123-login" references "PROJ-123"`,
+		Span:       newSpan(1, 14, 22),
+		Suggestion: "PROJ-123",
+	}, checkBranchIssueKey(ParseMessage("feat(file) : PROJ-456 a feature\n"), "feature/PROJ-123-login", projects))
+	assert.Equal(t, &Violation{
+		Message:    `issue key "PROJ-123" from branch "feature/PROJ-123-login" is not referenced in commit message`,
+		Span:       newSpan(1, 1, 23),
+		Suggestion: "PROJ-123",
+	}, checkBranchIssueKey(ParseMessage("feat(file) : a feature\n"), "feature/PROJ-123-login", projects))
+}
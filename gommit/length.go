@@ -0,0 +1,76 @@
+package gommit
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"golang.org/x/text/width"
+)
+
+// LengthMode defines how the length of a text is measured
+type LengthMode string
+
+// Length modes, runes counts every character as one, display width counts
+// East Asian wide and fullwidth characters as two
+const (
+	LengthModeRunes        LengthMode = "runes"
+	LengthModeDisplayWidth LengthMode = "display-width"
+)
+
+// ParseLengthMode converts a length mode name to a LengthMode
+func ParseLengthMode(name string) (LengthMode, error) {
+	switch LengthMode(name) {
+	case LengthModeRunes, LengthModeDisplayWidth:
+		return LengthMode(name), nil
+	}
+
+	return LengthModeRunes, fmt.Errorf(`length mode "%s" doesn't exist, it must be runes or display-width`, name)
+}
+
+// runeWidth returns the number of columns used to display a character
+func runeWidth(r rune, mode LengthMode) int {
+	if mode != LengthModeDisplayWidth {
+		return 1
+	}
+
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// measureLength returns the length of a text according to mode
+func measureLength(text string, mode LengthMode) int {
+	if mode != LengthModeDisplayWidth {
+		return utf8.RuneCountInString(text)
+	}
+
+	length := 0
+
+	for _, r := range text {
+		length += runeWidth(r, mode)
+	}
+
+	return length
+}
+
+// overflowColumn returns the column, counted in characters, of the first character
+// making a text longer than limit according to mode
+func overflowColumn(text string, limit int, mode LengthMode) int {
+	length := 0
+	column := 1
+
+	for _, r := range text {
+		length += runeWidth(r, mode)
+
+		if length > limit {
+			return column
+		}
+
+		column++
+	}
+
+	return column
+}
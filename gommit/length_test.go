@@ -0,0 +1,60 @@
+package gommit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLengthMode(t *testing.T) {
+	for _, name := range []string{"runes", "display-width"} {
+		mode, err := ParseLengthMode(name)
+
+		assert.NoError(t, err)
+		assert.Equal(t, LengthMode(name), mode)
+	}
+
+	_, err := ParseLengthMode("bytes")
+
+	assert.EqualError(t, err, `length mode "bytes" doesn't exist, it must be runes or display-width`)
+}
+
+func TestMeasureLength(t *testing.T) {
+	assert.Equal(t, 6, measureLength("résumé", LengthModeRunes))
+	assert.Equal(t, 6, measureLength("résumé", LengthModeDisplayWidth))
+	assert.Equal(t, 5, measureLength("応答を処理", LengthModeRunes))
+	assert.Equal(t, 10, measureLength("応答を処理", LengthModeDisplayWidth))
+	assert.Equal(t, 4, measureLength("ｆix", LengthModeDisplayWidth), "Must count fullwidth characters as two columns")
+	assert.Equal(t, 3, measureLength("ｱｲｳ", LengthModeDisplayWidth), "Must count halfwidth characters as one column")
+}
+
+func TestOverflowColumn(t *testing.T) {
+	assert.Equal(t, 5, overflowColumn("résumé", 4, LengthModeRunes))
+	assert.Equal(t, 3, overflowColumn("応答を処理", 4, LengthModeDisplayWidth))
+	assert.Equal(t, 3, overflowColumn("応答を処理", 5, LengthModeDisplayWidth), "Must point to the character crossing the limit")
+}
+
+func TestMatchMessageQueryWithSummaryLengthModes(t *testing.T) {
+	q := MessageQuery{
+		Message:  "fix(api) : 応答を処理する",
+		Matchers: map[string]string{"simple": "(?:fix|feat)\\(.*?\\) : .*"},
+		Options: Options{
+			CheckSummaryLength: true,
+			SummaryLength:      20,
+		},
+	}
+
+	m, err := MatchMessageQuery(q)
+
+	assert.NoError(t, err, "Must return no error")
+	assert.True(t, IsZeroMatching(m), "Must count characters by default")
+
+	q.Options.SummaryLengthMode = LengthModeDisplayWidth
+
+	m, err = MatchMessageQuery(q)
+
+	assert.NoError(t, err, "Must return no error")
+	assert.Equal(t, []Violation{
+		{RuleID: RuleSummaryLength, Message: "commit summary length is 25 columns, greater than 20 columns", Span: newSpan(1, 16, 19)},
+	}, m.Violations)
+}
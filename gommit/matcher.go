@@ -0,0 +1,141 @@
+package gommit
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/dlclark/regexp2"
+)
+
+// matcher is a compiled regexp identified by its name in configuration
+type matcher struct {
+	name    string
+	pattern string
+	regexp  *regexp2.Regexp
+}
+
+// matcherSet is a list of matchers sorted by name
+type matcherSet []matcher
+
+// compiledMatchers holds every regexp of a query, they are compiled once
+// and shared by every commit checked by this query
+type compiledMatchers struct {
+	templates       matcherSet
+	merges          matcherSet
+	deny            matcherSet
+	trailerPatterns matcherSet
+	exemptions      []compiledExemption
+	timeout         time.Duration
+}
+
+// compileMatchers compiles matchers, merge matchers, deny matchers, trailer patterns and exemptions of a query,
+// the first invalid regexp found is reported with its name
+func compileMatchers(matchers map[string]string, options Options) (*compiledMatchers, error) {
+	templates, err := compileMatcherSet(matchers, options.MatchTimeout, `regexp "%s" identified by "%s" is not a valid regexp, please check the syntax`)
+	if err != nil {
+		return nil, err
+	}
+
+	if !options.ConventionalCommits {
+		if err := checkNamedGroup(templates, "type", options.Types); err != nil {
+			return nil, err
+		}
+
+		if err := checkNamedGroup(templates, "scope", options.Scopes); err != nil {
+			return nil, err
+		}
+	}
+
+	merges, err := compileMatcherSet(options.MergeMatchers, options.MatchTimeout, `regexp "%s" identified by "%s" in merge-matchers section is not a valid regexp, please check the syntax`)
+	if err != nil {
+		return nil, err
+	}
+
+	deny, err := compileMatcherSet(options.DenyMatchers, options.MatchTimeout, `regexp "%s" identified by "%s" in deny section is not a valid regexp, please check the syntax`)
+	if err != nil {
+		return nil, err
+	}
+
+	trailerPatterns, err := compileMatcherSet(options.TrailerPatterns, options.MatchTimeout, `regexp "%s" defined for trailer "%s" is not a valid regexp, please check the syntax`)
+	if err != nil {
+		return nil, err
+	}
+
+	exemptions, err := compileExemptions(options.Exemptions, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &compiledMatchers{templates: templates, merges: merges, deny: deny, trailerPatterns: trailerPatterns, exemptions: exemptions, timeout: options.MatchTimeout}, nil
+}
+
+// checkNamedGroup ensures every matcher defines the named group a list of allowed values is checked
+// against, a message matching a matcher without this group would never be checked
+func checkNamedGroup(matchers matcherSet, group string, allowed []string) error {
+	if len(allowed) == 0 {
+		return nil
+	}
+
+	for _, matcher := range matchers {
+		if !slices.Contains(matcher.regexp.GetGroupNames(), group) {
+			return fmt.Errorf(`regexp "%s" identified by "%s" must define a named group "%s" as allowed %ss are defined, e.g. (?<%s>...)`, matcher.pattern, matcher.name, group, group, group)
+		}
+	}
+
+	return nil
+}
+
+// compileMatcherSet compiles regexps sorted by name, a match times out after timeout,
+// a zero timeout means a match never times out, errorFormat receives the pattern
+// and the name of an invalid regexp
+func compileMatcherSet(patterns map[string]string, timeout time.Duration, errorFormat string) (matcherSet, error) {
+	set := matcherSet{}
+
+	for _, name := range slices.Sorted(maps.Keys(patterns)) {
+		r, err := regexp2.Compile(patterns[name], 0)
+		if err != nil {
+			return nil, fmt.Errorf(errorFormat, patterns[name], name)
+		}
+
+		if timeout > 0 {
+			r.MatchTimeout = timeout
+		}
+
+		set = append(set, matcher{name: name, pattern: patterns[name], regexp: r})
+	}
+
+	return set, nil
+}
+
+// match tries to match a text, an error is returned if match timed out
+func (m matcher) match(text string) (bool, error) {
+	return m.regexp.MatchString(text)
+}
+
+// groups returns named groups captured when matching a text
+func (m matcher) groups(text string) map[string]string {
+	groups := map[string]string{}
+
+	match, err := m.regexp.FindStringMatch(text)
+	if err != nil || match == nil {
+		return groups
+	}
+
+	for _, name := range m.regexp.GetGroupNames() {
+		if g := match.GroupByName(name); g != nil && len(g.Captures) > 0 {
+			groups[name] = g.String()
+		}
+	}
+
+	return groups
+}
+
+// matchTimeoutViolation reports a matcher which took too long to be evaluated
+func matchTimeoutViolation(name string, timeout time.Duration) Violation {
+	return Violation{
+		RuleID:  RuleMatchTimeout,
+		Message: fmt.Sprintf(`matcher "%s" timed out after %s, check it for catastrophic backtracking`, name, timeout),
+	}
+}
@@ -0,0 +1,131 @@
+package gommit
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+)
+
+func mustCompileMatcherSet(patterns map[string]string) matcherSet {
+	set, err := compileMatcherSet(patterns, 0, `regexp "%s" identified by "%s" is not valid`)
+	if err != nil {
+		panic(err)
+	}
+
+	return set
+}
+
+func TestCompileMatchers(t *testing.T) {
+	matchers, err := compileMatchers(map[string]string{"b": "b", "a": "a"}, Options{
+		DenyMatchers:    map[string]string{"wip": "WIP"},
+		TrailerPatterns: map[string]string{"Refs": "^[A-Z]+-\\d+$"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "a", matchers.templates[0].name, "Must sort matchers by name")
+	assert.Equal(t, "b", matchers.templates[1].name, "Must sort matchers by name")
+	assert.Equal(t, "wip", matchers.deny[0].name)
+	assert.Equal(t, "^[A-Z]+-\\d+$", matchers.trailerPatterns[0].pattern)
+
+	type scenario struct {
+		matchers map[string]string
+		options  Options
+		err      string
+	}
+
+	scenarios := []scenario{
+		{map[string]string{"all": "**"}, Options{}, `regexp "**" identified by "all" is not a valid regexp, please check the syntax`},
+		{map[string]string{}, Options{MergeMatchers: map[string]string{"branch": "(Merge"}}, `regexp "(Merge" identified by "branch" in merge-matchers section is not a valid regexp, please check the syntax`},
+		{map[string]string{}, Options{DenyMatchers: map[string]string{"wip": "(WIP"}}, `regexp "(WIP" identified by "wip" in deny section is not a valid regexp, please check the syntax`},
+		{map[string]string{}, Options{TrailerPatterns: map[string]string{"Refs": "[A-Z"}}, `regexp "[A-Z" defined for trailer "Refs" is not a valid regexp, please check the syntax`},
+	}
+
+	for _, s := range scenarios {
+		_, err := compileMatchers(s.matchers, s.options)
+
+		assert.EqualError(t, err, s.err)
+	}
+}
+
+func TestMatchQueriesWithAnInvalidMatcher(t *testing.T) {
+	_, err := MatchMessageQuery(MessageQuery{Message: "whatever", Matchers: map[string]string{"all": "**"}})
+
+	assert.EqualError(t, err, `regexp "**" identified by "all" is not a valid regexp, please check the syntax`)
+
+	_, err = MatchCommitQuery(CommitQuery{Path: "testtesttest", Matchers: map[string]string{"all": "**"}})
+
+	assert.EqualError(t, err, `regexp "**" identified by "all" is not a valid regexp, please check the syntax`, "Must report invalid matchers before reading repository")
+
+	_, err = MatchRangeQuery(RangeQuery{Path: "testtesttest", Matchers: map[string]string{"all": "**"}})
+
+	assert.EqualError(t, err, `regexp "**" identified by "all" is not a valid regexp, please check the syntax`, "Must report invalid matchers before reading repository")
+}
+
+func TestMatcherGroups(t *testing.T) {
+	m := mustCompileMatcherSet(map[string]string{"simple": "(?<type>\\w+)(?:\\((?<scope>.*?)\\))? : .*"})[0]
+
+	assert.Equal(t, map[string]string{"0": "feat(api) : a feature", "type": "feat", "scope": "api"}, m.groups("feat(api) : a feature"))
+	assert.Equal(t, map[string]string{"0": "feat : a feature", "type": "feat"}, m.groups("feat : a feature"), "Must not return groups which didn't capture anything")
+	assert.Equal(t, map[string]string{}, m.groups("whatever"))
+}
+
+// syntheticHistory creates size commits which are not stored in any repository
+func syntheticHistory(size int) *[]*object.Commit {
+	commits := make([]*object.Commit, 0, size)
+
+	for i := range size {
+		commits = append(commits, &object.Commit{
+			Hash:    plumbing.ComputeHash(plumbing.CommitObject, []byte(strconv.Itoa(i))),
+			Message: fmt.Sprintf("feat(file%d) : new file %d\n\ncreate a new file %d\n\nRefs: PROJ-%d\n", i, i, i, i),
+		})
+	}
+
+	return &commits
+}
+
+var benchmarkMatchers = map[string]string{
+	"feature":  "(?<type>feat)\\((?<scope>.*?)\\) : .*?\\n\\n.*?\\n",
+	"fix":      "(?<type>fix)\\((?<scope>.*?)\\) : .*?\\n\\n.*?\\n",
+	"refactor": "(?<type>ref)\\((?<scope>.*?)\\) : .*?\\n\\n.*?\\n",
+}
+
+var benchmarkOptions = Options{
+	Types:           []string{"feat", "fix", "ref"},
+	DenyMatchers:    map[string]string{"wip": "(?i)\\bwip\\b", "fixup": "^fixup! "},
+	TrailerPatterns: map[string]string{"Refs": "^[A-Z]+-\\d+$"},
+	IssueProjects:   []string{"PROJ"},
+}
+
+func BenchmarkAnalyzeCommits(b *testing.B) {
+	for _, size := range []int{1000, 20000} {
+		commits := syntheticHistory(size)
+
+		b.Run(fmt.Sprintf("%d commits with matchers compiled once", size), func(b *testing.B) {
+			for b.Loop() {
+				matchers, err := compileMatchers(benchmarkMatchers, benchmarkOptions)
+				if err != nil {
+					b.Fatal(err)
+				}
+
+				analyzeCommits(commits, ruleSet{rules: buildRules(matchers, benchmarkOptions)}, benchmarkOptions, 1)
+			}
+		})
+
+		b.Run(fmt.Sprintf("%d commits with matchers compiled for every commit", size), func(b *testing.B) {
+			for b.Loop() {
+				for _, commit := range *commits {
+					matchers, err := compileMatchers(benchmarkMatchers, benchmarkOptions)
+					if err != nil {
+						b.Fatal(err)
+					}
+
+					analyzeCommit(commit, ruleSet{rules: buildRules(matchers, benchmarkOptions)}, benchmarkOptions)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package gommit
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// trailerRegexp matches a trailer line as understood by git interpret-trailers
+var trailerRegexp = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9-]*)[ \t]*:[ \t]*(.*)$`)
+
+// gitGeneratedTrailerPrefixes are prefixes of lines added by git itself, a
+// paragraph containing one of them is a trailer block if at least 25% of its
+// lines are trailers
+var gitGeneratedTrailerPrefixes = []string{"Signed-off-by: ", "(cherry picked from commit "}
+
+// Message represents a commit message split into its parts
+type Message struct {
+	Raw       string
+	Lines     []string
+	Summary   string
+	Separator int
+	Body      []Paragraph
+	Trailers  []Trailer
+}
+
+// Paragraph represents a block of consecutive non blank lines in a commit body,
+// Line is the line number of its first line starting from 1
+type Paragraph struct {
+	Line  int
+	Lines []string
+}
+
+// Trailer represents a "key: value" line from the trailer block ending a commit message,
+// Line is the line number of the key starting from 1
+type Trailer struct {
+	Key   string
+	Value string
+	Line  int
+}
+
+// String returns paragraph lines joined by a new line
+func (p Paragraph) String() string {
+	return strings.Join(p.Lines, "\n")
+}
+
+// ParseMessage splits a raw commit message into a summary, a separator made of blank
+// lines, body paragraphs and a trailer block
+func ParseMessage(raw string) *Message {
+	lines := strings.Split(strings.TrimSuffix(raw, "\n"), "\n")
+	message := Message{
+		Raw:     raw,
+		Lines:   lines,
+		Summary: lines[0],
+	}
+
+	i := 1
+
+	for ; i < len(lines) && isBlankLine(lines[i]); i++ {
+		message.Separator++
+	}
+
+	paragraphs := splitParagraphs(lines, i)
+
+	if len(paragraphs) > 0 {
+		if trailers, ok := parseTrailerBlock(paragraphs[len(paragraphs)-1]); ok {
+			message.Trailers = trailers
+			paragraphs = paragraphs[:len(paragraphs)-1]
+		}
+	}
+
+	message.Body = paragraphs
+
+	return &message
+}
+
+// isBlankLine returns true if a line contains only whitespaces
+func isBlankLine(line string) bool {
+	return strings.TrimSpace(line) == ""
+}
+
+// splitParagraphs groups lines starting at index start into paragraphs separated by blank lines
+func splitParagraphs(lines []string, start int) []Paragraph {
+	paragraphs := []Paragraph{}
+	var current *Paragraph
+
+	for i := start; i < len(lines); i++ {
+		if isBlankLine(lines[i]) {
+			current = nil
+
+			continue
+		}
+
+		if current == nil {
+			paragraphs = append(paragraphs, Paragraph{Line: i + 1})
+			current = &paragraphs[len(paragraphs)-1]
+		}
+
+		current.Lines = append(current.Lines, lines[i])
+	}
+
+	return paragraphs
+}
+
+// parseTrailerBlock extracts trailers from a paragraph following git interpret-trailers
+// rules : every line must be a trailer or a continuation line, or at least 25% of lines
+// must be trailers and one of them must be generated by git. A line generated by git like
+// "(cherry picked from commit <sha>)" counts as a trailer line even without a key
+func parseTrailerBlock(paragraph Paragraph) ([]Trailer, bool) {
+	trailers := []Trailer{}
+	trailerLines := 0
+	otherLines := 0
+	hasGitGenerated := false
+	inTrailer := false
+
+	for i, line := range paragraph.Lines {
+		isGitGenerated := slices.ContainsFunc(gitGeneratedTrailerPrefixes, func(prefix string) bool {
+			return strings.HasPrefix(line, prefix)
+		})
+		hasGitGenerated = hasGitGenerated || isGitGenerated
+
+		if inTrailer && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			trailers[len(trailers)-1].Value += " " + strings.TrimSpace(line)
+
+			continue
+		}
+
+		if chunks := trailerRegexp.FindStringSubmatch(line); chunks != nil {
+			trailers = append(trailers, Trailer{Key: chunks[1], Value: strings.TrimSpace(chunks[2]), Line: paragraph.Line + i})
+			trailerLines++
+			inTrailer = true
+
+			continue
+		}
+
+		if isGitGenerated {
+			trailerLines++
+			inTrailer = false
+
+			continue
+		}
+
+		otherLines++
+		inTrailer = false
+	}
+
+	if trailerLines == 0 {
+		return nil, false
+	}
+
+	if otherLines == 0 || (hasGitGenerated && trailerLines*3 >= otherLines) {
+		return trailers, true
+	}
+
+	return nil, false
+}
@@ -0,0 +1,125 @@
+package gommit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMessage(t *testing.T) {
+	type scenario struct {
+		name    string
+		message string
+		test    func(*Message)
+	}
+
+	scenarios := []scenario{
+		{
+			"Summary only",
+			"feat(file) : a summary\n",
+			func(m *Message) {
+				assert.Equal(t, "feat(file) : a summary", m.Summary)
+				assert.Equal(t, 0, m.Separator)
+				assert.Empty(t, m.Body)
+				assert.Empty(t, m.Trailers)
+				assert.Equal(t, []string{"feat(file) : a summary"}, m.Lines)
+			},
+		},
+		{
+			"Summary with body paragraphs",
+			"feat(file) : a summary\n\nfirst paragraph\nstill first paragraph\n\nsecond paragraph\n",
+			func(m *Message) {
+				assert.Equal(t, "feat(file) : a summary", m.Summary)
+				assert.Equal(t, 1, m.Separator)
+				assert.Equal(t, []Paragraph{
+					{Line: 3, Lines: []string{"first paragraph", "still first paragraph"}},
+					{Line: 6, Lines: []string{"second paragraph"}},
+				}, m.Body)
+				assert.Equal(t, "first paragraph\nstill first paragraph", m.Body[0].String())
+				assert.Empty(t, m.Trailers)
+			},
+		},
+		{
+			"Summary with several separator lines",
+			"feat(file) : a summary\n\n\n\nbody\n",
+			func(m *Message) {
+				assert.Equal(t, 3, m.Separator)
+				assert.Equal(t, []Paragraph{{Line: 5, Lines: []string{"body"}}}, m.Body)
+			},
+		},
+		{
+			"Summary with body and trailers",
+			"feat(file) : a summary\n\nbody\n\nSigned-off-by: John Doe <john@example.com>\nRefs: PROJ-123\n  PROJ-124\n",
+			func(m *Message) {
+				assert.Equal(t, []Paragraph{{Line: 3, Lines: []string{"body"}}}, m.Body)
+				assert.Equal(t, []Trailer{
+					{Key: "Signed-off-by", Value: "John Doe <john@example.com>", Line: 5},
+					{Key: "Refs", Value: "PROJ-123 PROJ-124", Line: 6},
+				}, m.Trailers)
+			},
+		},
+		{
+			"Summary with trailers only",
+			"feat(file) : a summary\n\nReviewed-by: John Doe <john@example.com>\n",
+			func(m *Message) {
+				assert.Empty(t, m.Body)
+				assert.Equal(t, []Trailer{{Key: "Reviewed-by", Value: "John Doe <john@example.com>", Line: 3}}, m.Trailers)
+			},
+		},
+		{
+			"Last paragraph is not a trailer block",
+			"feat(file) : a summary\n\nbody\n\nNote: something\nthis is not a trailer\n",
+			func(m *Message) {
+				assert.Len(t, m.Body, 2)
+				assert.Empty(t, m.Trailers)
+			},
+		},
+		{
+			"Last paragraph is a trailer block with git generated trailer",
+			"feat(file) : a summary\n\nbody\n\nthis is not a trailer\nSigned-off-by: John Doe <john@example.com>\n",
+			func(m *Message) {
+				assert.Len(t, m.Body, 1)
+				assert.Equal(t, []Trailer{{Key: "Signed-off-by", Value: "John Doe <john@example.com>", Line: 6}}, m.Trailers)
+			},
+		},
+		{
+			"Last paragraph is a cherry pick line",
+			"feat(file) : a summary\n\nbody\n\n(cherry picked from commit 5ad8f1b0c0ffee5ad8f1b0c0ffee5ad8f1b0c0ff)\n",
+			func(m *Message) {
+				assert.Equal(t, []Paragraph{{Line: 3, Lines: []string{"body"}}}, m.Body)
+				assert.Empty(t, m.Trailers)
+			},
+		},
+		{
+			"Last paragraph is a trailer block with a cherry pick line",
+			"feat(file) : a summary\n\nbody\n\nthis is not a trailer\nReviewed-by: John Doe <john@example.com>\n(cherry picked from commit 5ad8f1b)\n",
+			func(m *Message) {
+				assert.Len(t, m.Body, 1)
+				assert.Equal(t, []Trailer{{Key: "Reviewed-by", Value: "John Doe <john@example.com>", Line: 6}}, m.Trailers)
+			},
+		},
+		{
+			"Summary looking like a trailer",
+			"Signed-off-by: John Doe <john@example.com>",
+			func(m *Message) {
+				assert.Equal(t, "Signed-off-by: John Doe <john@example.com>", m.Summary)
+				assert.Empty(t, m.Trailers)
+			},
+		},
+		{
+			"Empty message",
+			"",
+			func(m *Message) {
+				assert.Equal(t, "", m.Summary)
+				assert.Empty(t, m.Body)
+				assert.Empty(t, m.Trailers)
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			s.test(ParseMessage(s.message))
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package gommit
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+
+	"github.com/antham/gommit/reference"
+)
+
+// openRepository opens repository containing path, parent directories are searched
+// like git does when path is a subdirectory of a working tree
+func openRepository(repoPath string) (*git.Repository, error) {
+	if _, err := os.Stat(repoPath); errors.Is(err, os.ErrNotExist) {
+		return nil, git.ErrRepositoryNotExists
+	}
+
+	return git.PlainOpenWithOptions(repoPath, &git.PlainOpenOptions{DetectDotGit: true})
+}
+
+// commitResolver looks up commits in a repository, lookups are serialized
+// as commits of a range can be analyzed concurrently, the first commit date
+// is looked up once
+type commitResolver struct {
+	repo            *git.Repository
+	mu              sync.Mutex
+	firstCommitDate *time.Time
+}
+
+// newCommitResolver opens repository to look up commits, nil is returned when path is not a repository
+func newCommitResolver(repoPath string) (*commitResolver, error) {
+	repo, err := openRepository(repoPath)
+	if errors.Is(err, git.ErrRepositoryNotExists) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &commitResolver{repo: repo}, nil
+}
+
+// exists returns true if a commit identified by a full or an abbreviated ID exists
+func (r *commitResolver) exists(ID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return reference.CommitExists(r.repo, ID)
+}
+
+// firstDate returns the author date of the first commit of repository
+func (r *commitResolver) firstDate() (time.Time, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.firstCommitDate != nil {
+		return *r.firstCommitDate, nil
+	}
+
+	date, err := reference.FetchFirstCommitDate(r.repo)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	r.firstCommitDate = &date
+
+	return date, nil
+}
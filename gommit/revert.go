@@ -0,0 +1,97 @@
+package gommit
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// revertSummaryRegexp matches a summary written by git revert
+var revertSummaryRegexp = regexp.MustCompile(`^Revert "(.*)"$`)
+
+// revertReferenceRegexp matches the sentence git revert writes to reference the reverted commit,
+// a reverted merge commit is followed by ", reversing changes made to <sha>."
+var revertReferenceRegexp = regexp.MustCompile(`^This reverts commit ([0-9a-f]{4,40})[.,]`)
+
+// matcherRules are rules checking the format of a message,
+// revert commits bypass them when it's configured
+var matcherRules = []string{RuleTemplate, RuleConventionalCommits, RuleType, RuleScope}
+
+// isRevertMessage returns true if a message summary was written by git revert
+func isRevertMessage(message *Message) bool {
+	return revertSummaryRegexp.MatchString(message.Summary)
+}
+
+// isBypassedRule returns true if a rule must not check a message, revert
+// commits bypass matcher rules when options allow it and revert commits are checked
+func isBypassedRule(ID string, message *Message, options Options) bool {
+	return options.CheckReverts && options.RevertBypassMatchers && slices.Contains(matcherRules, ID) && isRevertMessage(message)
+}
+
+// revertRule ensures a revert commit references a commit existing in repository,
+// and optionally explains why it's reverted, the referenced commit is not looked up
+// when no repository is available
+type revertRule struct {
+	requireReason bool
+	resolver      *commitResolver
+}
+
+func (r revertRule) ID() string {
+	return RuleRevert
+}
+
+func (r revertRule) Check(message *Message, commit *object.Commit) []Violation {
+	matches := revertSummaryRegexp.FindStringSubmatch(message.Summary)
+	if matches == nil {
+		return nil
+	}
+
+	violations := []Violation{}
+	hasReason := false
+	var ID string
+	var line int
+
+	for _, paragraph := range message.Body {
+		if m := revertReferenceRegexp.FindStringSubmatch(paragraph.Lines[0]); m != nil && ID == "" {
+			ID = m[1]
+			line = paragraph.Line
+
+			continue
+		}
+
+		hasReason = true
+	}
+
+	if ID == "" {
+		violations = append(violations, Violation{
+			Message: `revert commit must reference the reverted commit with "This reverts commit <sha>."`,
+			Span:    message.lineSpan(1),
+		})
+	} else if r.resolver != nil {
+		exists, err := r.resolver.exists(ID)
+
+		switch {
+		case err != nil:
+			violations = append(violations, Violation{
+				Message: fmt.Sprintf(`reverted commit "%s" can't be looked up : %s`, ID, err),
+				Span:    message.substringSpan(line, ID),
+			})
+		case !exists:
+			violations = append(violations, Violation{
+				Message: fmt.Sprintf(`reverted commit "%s" can't be found in repository`, ID),
+				Span:    message.substringSpan(line, ID),
+			})
+		}
+	}
+
+	if r.requireReason && !hasReason {
+		violations = append(violations, Violation{
+			Message: fmt.Sprintf(`revert commit must explain in a paragraph why "%s" is reverted`, matches[1]),
+			Span:    message.lineSpan(1),
+		})
+	}
+
+	return violations
+}
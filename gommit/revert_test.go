@@ -0,0 +1,150 @@
+package gommit
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRevertRule(t *testing.T) {
+	type scenario struct {
+		name          string
+		message       string
+		requireReason bool
+		violations    []Violation
+	}
+
+	scenarios := []scenario{
+		{
+			"Not a revert commit",
+			"feat(file) : a feature\n",
+			true,
+			[]Violation{},
+		},
+		{
+			"Revert commit",
+			"Revert \"feat(file) : a feature\"\n\nThis reverts commit 5ad8f1b.\n",
+			false,
+			[]Violation{},
+		},
+		{
+			"Revert commit of a merge commit",
+			"Revert \"Merge branch 'test'\"\n\nThis reverts commit 5ad8f1b, reversing\nchanges made to 09f25db.\n\nIt broke the build.\n",
+			true,
+			[]Violation{},
+		},
+		{
+			"Revert commit without a reference",
+			"Revert \"feat(file) : a feature\"\n\nIt broke the build.\n",
+			false,
+			[]Violation{{Message: `revert commit must reference the reverted commit with "This reverts commit <sha>."`, Span: newSpan(1, 1, 32)}},
+		},
+		{
+			"Revert commit without a reason",
+			"Revert \"feat(file) : a feature\"\n\nThis reverts commit 5ad8f1b.\n\nSigned-off-by: John Doe <john@example.com>\n",
+			true,
+			[]Violation{{Message: `revert commit must explain in a paragraph why "feat(file) : a feature" is reverted`, Span: newSpan(1, 1, 32)}},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			violations := revertRule{requireReason: s.requireReason}.Check(ParseMessage(s.message), nil)
+
+			if len(s.violations) == 0 {
+				assert.Empty(t, violations)
+
+				return
+			}
+
+			assert.Equal(t, s.violations, violations)
+		})
+	}
+}
+
+func TestIsBypassedRule(t *testing.T) {
+	revert := ParseMessage("Revert \"feat(file) : a feature\"\n\nThis reverts commit 5ad8f1b.\n")
+	message := ParseMessage("feat(file) : a feature\n")
+
+	assert.True(t, isBypassedRule(RuleTemplate, revert, Options{CheckReverts: true, RevertBypassMatchers: true}))
+	assert.True(t, isBypassedRule(RuleConventionalCommits, revert, Options{CheckReverts: true, RevertBypassMatchers: true}))
+	assert.False(t, isBypassedRule(RuleSummaryLength, revert, Options{CheckReverts: true, RevertBypassMatchers: true}), "Must bypass matcher rules only")
+	assert.False(t, isBypassedRule(RuleTemplate, revert, Options{CheckReverts: true}), "Must bypass rules only when it's configured")
+	assert.False(t, isBypassedRule(RuleTemplate, revert, Options{RevertBypassMatchers: true}), "Must bypass rules only when revert commits are checked")
+	assert.False(t, isBypassedRule(RuleTemplate, message, Options{CheckReverts: true, RevertBypassMatchers: true}), "Must bypass rules only for revert commits")
+}
+
+func TestMatchMessageQueryWithARevertCommit(t *testing.T) {
+	type scenario struct {
+		name       string
+		options    Options
+		violations []Violation
+	}
+
+	scenarios := []scenario{
+		{
+			"Revert rule disabled",
+			Options{},
+			[]Violation{{RuleID: RuleTemplate, Message: "no template match commit message"}},
+		},
+		{
+			"Revert commit bypassing matchers without revert rule",
+			Options{RevertBypassMatchers: true},
+			[]Violation{{RuleID: RuleTemplate, Message: "no template match commit message"}},
+		},
+		{
+			"Revert commit bypassing matchers",
+			Options{CheckReverts: true, RevertRequireReason: true, RevertBypassMatchers: true},
+			[]Violation{{RuleID: RuleRevert, Message: `revert commit must explain in a paragraph why "update file" is reverted`, Span: newSpan(1, 1, 21)}},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			q := MessageQuery{
+				Message:  "Revert \"update file\"\n\nThis reverts commit 5ad8f1b.\n",
+				Matchers: map[string]string{"simple": "^feat\\(.*?\\) : .*"},
+				Options:  s.options,
+			}
+
+			m, err := MatchMessageQuery(q)
+
+			assert.NoError(t, err, "Must return no error")
+			assert.Equal(t, s.violations, m.Violations)
+		})
+	}
+}
+
+func TestMatchRangeQueryWithRevertCommits(t *testing.T) {
+	for _, filename := range []string{"../features/repo.sh", "../features/revert-commits.sh"} {
+		err := exec.Command(filename).Run()
+		if err != nil {
+			logrus.Fatal(err)
+		}
+	}
+
+	q := RangeQuery{
+		Path:     "testing-repository/",
+		From:     "test~3",
+		To:       "test",
+		Matchers: map[string]string{"simple": "^feat\\(.*?\\) : .*"},
+		Options: Options{
+			CheckReverts:         true,
+			RevertRequireReason:  true,
+			RevertBypassMatchers: true,
+		},
+	}
+
+	m, err := MatchRangeQuery(q)
+
+	assert.NoError(t, err, "Must return no errors")
+	assert.Len(t, *m, 2)
+	assert.Equal(t, []Violation{
+		{RuleID: RuleRevert, Message: `reverted commit "0123456789abcdef0123456789abcdef01234567" can't be found in repository`, Span: newSpan(3, 21, 61)},
+	}, (*m)[0].Violations)
+	assert.Equal(t, []Violation{
+		{RuleID: RuleRevert, Message: `revert commit must explain in a paragraph why "feat(file8) : new file 8" is reverted`, Span: newSpan(1, 1, 34)},
+	}, (*m)[1].Violations, "Must find reverted commit in repository")
+}
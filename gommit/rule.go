@@ -0,0 +1,415 @@
+package gommit
+
+import (
+	"fmt"
+	"slices"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// Rule checks a commit message and returns every violation found, commit is nil
+// when a message is checked on its own. Violations without a rule ID get the rule ID
+// and the severity configured for a rule overrides the one given by a violation
+type Rule interface {
+	ID() string
+	Check(message *Message, commit *object.Commit) []Violation
+}
+
+// RangeRule is a Rule checking every commit of a range at once, violations are indexed by the
+// position in commits of the commit they're reported on, commits are sorted from the newest
+// to the oldest. CheckRange is only called when checking a range, Check is called like for any
+// other rule
+type RangeRule interface {
+	Rule
+	CheckRange(commits []*object.Commit) map[int][]Violation
+}
+
+// funcRule is a Rule defined from a function
+type funcRule struct {
+	id    string
+	check func(message *Message, commit *object.Commit) []Violation
+}
+
+// NewRule creates a Rule from an ID and a check function
+func NewRule(ID string, check func(message *Message, commit *object.Commit) []Violation) Rule {
+	return funcRule{id: ID, check: check}
+}
+
+func (r funcRule) ID() string {
+	return r.id
+}
+
+func (r funcRule) Check(message *Message, commit *object.Commit) []Violation {
+	return r.check(message, commit)
+}
+
+// funcRangeRule is a RangeRule defined from a function
+type funcRangeRule struct {
+	id    string
+	check func(commits []*object.Commit) map[int][]Violation
+}
+
+// NewRangeRule creates a RangeRule from an ID and a check function run against a whole range
+func NewRangeRule(ID string, check func(commits []*object.Commit) map[int][]Violation) RangeRule {
+	return funcRangeRule{id: ID, check: check}
+}
+
+func (r funcRangeRule) ID() string {
+	return r.id
+}
+
+func (r funcRangeRule) Check(message *Message, commit *object.Commit) []Violation {
+	return nil
+}
+
+func (r funcRangeRule) CheckRange(commits []*object.Commit) map[int][]Violation {
+	return r.check(commits)
+}
+
+// buildRules creates built-in rules enabled by options, compiled matchers are shared by rules
+func buildRules(matchers *compiledMatchers, options Options) []Rule {
+	rules := []Rule{templateRule{matchers: matchers.templates, timeout: matchers.timeout}}
+
+	if options.CheckSummaryLength {
+		rules = append(rules, summaryLengthRule{length: options.SummaryLength, mode: options.SummaryLengthMode})
+	}
+
+	if options.CheckSummarySeparator {
+		rules = append(rules, summarySeparatorRule{})
+	}
+
+	if options.CheckBodyLineLength {
+		rules = append(rules, bodyLineLengthRule{length: options.BodyLineLength})
+	}
+
+	if options.ConventionalCommits {
+		rules = append(rules, conventionalCommitsRule{})
+	}
+
+	if options.CheckDuplicateSummaries {
+		rules = append(rules, duplicateSummaryRule{threshold: options.SummarySimilarity})
+	}
+
+	return append(rules,
+		autosquashRule{},
+		allowedValueRule{id: RuleType, allowed: options.Types, matchers: matchers.templates, conventionalCommits: options.ConventionalCommits},
+		allowedValueRule{id: RuleScope, allowed: options.Scopes, matchers: matchers.templates, conventionalCommits: options.ConventionalCommits},
+		trailersRule{options: options, patterns: matchers.trailerPatterns, timeout: matchers.timeout},
+		denyRule{matchers: matchers.deny, timeout: matchers.timeout},
+		issueKeyRule{projects: options.IssueProjects, exemptTypes: options.IssueExemptTypes, matchers: matchers.templates, conventionalCommits: options.ConventionalCommits},
+	)
+}
+
+// buildMergeRules creates rules merge commits are checked against, they must match a merge
+// matcher and must not contain denied content, nil is returned if no merge matcher is defined
+func buildMergeRules(matchers *compiledMatchers, customRules []Rule) []Rule {
+	if len(matchers.merges) == 0 {
+		return nil
+	}
+
+	return append([]Rule{
+		templateRule{matchers: matchers.merges, timeout: matchers.timeout},
+		denyRule{matchers: matchers.deny, timeout: matchers.timeout},
+	}, customRules...)
+}
+
+// buildRuleSet creates rules commits of repository found at path are checked against, custom
+// rules and rules looking up data in repository apply to merge commits and exempted commits too,
+// rules a commit can't waive apply to every commit even when its message is not checked
+func buildRuleSet(matchers *compiledMatchers, options Options, customRules []Rule, repoPath string) (ruleSet, error) {
+	repositoryRules, err := appendRepositoryRules(nil, repoPath, options)
+	if err != nil {
+		return ruleSet{}, err
+	}
+
+	extraRules := slices.Concat(customRules, repositoryRules)
+
+	return ruleSet{
+		rules:      append(buildRules(matchers, options), extraRules...),
+		mergeRules: buildMergeRules(matchers, extraRules),
+		exemptions: buildExemptionRules(matchers, options, extraRules),
+		trustRules: slices.DeleteFunc(slices.Clone(repositoryRules), func(rule Rule) bool { return isSuppressibleRule(rule.ID()) }),
+	}, nil
+}
+
+// ruleSet holds rules commits are checked against, commits matching an exemption
+// are checked against its rules, merge commits are checked against merge rules
+// when they are defined. Trust rules check who made a commit, they apply to commits
+// whose message is not checked
+type ruleSet struct {
+	rules      []Rule
+	mergeRules []Rule
+	exemptions []exemptionRules
+	trustRules []Rule
+}
+
+// rulesFor returns rules a commit message must be checked against and the name of the exemption
+// applying to it if any, rules are nil when commit message is not checked because commit is
+// exempted from every check or is a merge commit excluded by options
+func (s ruleSet) rulesFor(commit *object.Commit, options Options) (string, []Rule) {
+	if options.ExcludeMergeCommits && isMergeCommit(commit) {
+		return "", nil
+	}
+
+	for _, e := range s.exemptions {
+		if e.exemption.applies(commit) {
+			return e.exemption.name, e.rules
+		}
+	}
+
+	if s.mergeRules != nil && isMergeCommit(commit) {
+		return "", s.mergeRules
+	}
+
+	return "", s.rules
+}
+
+// extractTypeAndScope retrieves type and scope of a message from named groups of the first
+// matcher matching it, or from the message itself when conventional commits are enabled,
+// a matcher timing out is considered as not matching, the timeout is reported by templateRule
+func extractTypeAndScope(message *Message, matchers matcherSet, conventionalCommits bool) (string, string) {
+	if conventionalCommits {
+		commit, _ := ParseConventionalCommit(message)
+
+		return commit.Type, commit.Scope
+	}
+
+	for _, matcher := range matchers {
+		if ok, err := matcher.match(message.Raw); err == nil && ok {
+			groups := matcher.groups(message.Raw)
+
+			return groups["type"], groups["scope"]
+		}
+	}
+
+	return "", ""
+}
+
+// templateRule ensures a message matches at least one matcher,
+// every matcher timing out is reported
+type templateRule struct {
+	matchers matcherSet
+	timeout  time.Duration
+}
+
+func (r templateRule) ID() string {
+	return RuleTemplate
+}
+
+func (r templateRule) Check(message *Message, commit *object.Commit) []Violation {
+	if len(r.matchers) == 0 {
+		return nil
+	}
+
+	violations := []Violation{}
+
+	for _, matcher := range r.matchers {
+		ok, err := matcher.match(message.Raw)
+		if err != nil {
+			violations = append(violations, matchTimeoutViolation(matcher.name, r.timeout))
+
+			continue
+		}
+
+		if ok {
+			return violations
+		}
+	}
+
+	return append(violations, Violation{Message: "no template match commit message"})
+}
+
+// summaryLengthRule ensures summary is not too long, length is counted
+// in characters or in display columns depending on mode
+type summaryLengthRule struct {
+	length int
+	mode   LengthMode
+}
+
+func (r summaryLengthRule) ID() string {
+	return RuleSummaryLength
+}
+
+func (r summaryLengthRule) Check(message *Message, commit *object.Commit) []Violation {
+	if isValidSummaryLength(r.length, message.Summary, r.mode) {
+		return nil
+	}
+
+	unit := "characters"
+
+	if r.mode == LengthModeDisplayWidth {
+		unit = "columns"
+	}
+
+	return []Violation{{
+		Message: fmt.Sprintf("commit summary length is %d %s, greater than %d %s", measureLength(message.Summary, r.mode), unit, r.length, unit),
+		Span:    newSpan(1, overflowColumn(message.Summary, r.length, r.mode), utf8.RuneCountInString(message.Summary)+1),
+	}}
+}
+
+// summarySeparatorRule ensures summary is followed by exactly one blank line
+type summarySeparatorRule struct{}
+
+func (r summarySeparatorRule) ID() string {
+	return RuleSummarySeparator
+}
+
+func (r summarySeparatorRule) Check(message *Message, commit *object.Commit) []Violation {
+	if hasValidSeparator(message) {
+		return nil
+	}
+
+	return []Violation{{Message: "commit summary must be followed by exactly one blank line", Span: message.lineSpan(2)}}
+}
+
+// bodyLineLengthRule ensures body lines are not too long
+type bodyLineLengthRule struct {
+	length int
+}
+
+func (r bodyLineLengthRule) ID() string {
+	return RuleBodyLineLength
+}
+
+func (r bodyLineLengthRule) Check(message *Message, commit *object.Commit) []Violation {
+	violations := []Violation{}
+
+	for _, line := range findLongBodyLines(r.length, message) {
+		violations = append(violations, Violation{
+			Message: fmt.Sprintf("commit body line %d length is greater than %d characters", line, r.length),
+			Span:    newSpan(line, r.length+1, utf8.RuneCountInString(message.Lines[line-1])+1),
+		})
+	}
+
+	return violations
+}
+
+// conventionalCommitsRule ensures a message follows conventional commits specification
+type conventionalCommitsRule struct{}
+
+func (r conventionalCommitsRule) ID() string {
+	return RuleConventionalCommits
+}
+
+func (r conventionalCommitsRule) Check(message *Message, commit *object.Commit) []Violation {
+	_, violations := ParseConventionalCommit(message)
+
+	return violations
+}
+
+// allowedValueRule ensures type or scope of a message is part of allowed values
+type allowedValueRule struct {
+	id                  string
+	allowed             []string
+	matchers            matcherSet
+	conventionalCommits bool
+}
+
+func (r allowedValueRule) ID() string {
+	return r.id
+}
+
+func (r allowedValueRule) Check(message *Message, commit *object.Commit) []Violation {
+	if len(r.allowed) == 0 {
+		return nil
+	}
+
+	commitType, scope := extractTypeAndScope(message, r.matchers, r.conventionalCommits)
+	value := commitType
+
+	if r.id == RuleScope {
+		value = scope
+	}
+
+	if violation := checkAllowedValue(r.id, value, r.allowed); violation != nil {
+		violation.Span = message.substringSpan(1, value)
+
+		return []Violation{*violation}
+	}
+
+	return nil
+}
+
+// trailersRule ensures trailers requirements are fulfilled, Signed-off-by
+// trailers are compared to commit author when a commit is available
+type trailersRule struct {
+	options  Options
+	patterns matcherSet
+	timeout  time.Duration
+}
+
+func (r trailersRule) ID() string {
+	return RuleTrailers
+}
+
+func (r trailersRule) Check(message *Message, commit *object.Commit) []Violation {
+	violations := checkTrailers(message, r.options, r.patterns, r.timeout)
+
+	if r.options.CheckSignedOffBy && commit != nil {
+		if violation := checkSignedOffByAuthor(message, commit); violation != nil {
+			violations = append(violations, *violation)
+		}
+	}
+
+	return violations
+}
+
+// denyRule ensures a message doesn't contain forbidden content
+type denyRule struct {
+	matchers matcherSet
+	timeout  time.Duration
+}
+
+func (r denyRule) ID() string {
+	return RuleDeny
+}
+
+func (r denyRule) Check(message *Message, commit *object.Commit) []Violation {
+	return findDeniedContent(message.Raw, r.matchers, r.timeout)
+}
+
+// issueKeyRule ensures a message references an issue
+type issueKeyRule struct {
+	projects            []string
+	exemptTypes         []string
+	matchers            matcherSet
+	conventionalCommits bool
+}
+
+func (r issueKeyRule) ID() string {
+	return RuleIssueKey
+}
+
+func (r issueKeyRule) Check(message *Message, commit *object.Commit) []Violation {
+	if len(r.projects) == 0 {
+		return nil
+	}
+
+	commitType, _ := extractTypeAndScope(message, r.matchers, r.conventionalCommits)
+
+	if violation := checkIssueKey(message, commitType, r.projects, r.exemptTypes); violation != nil {
+		return []Violation{*violation}
+	}
+
+	return nil
+}
+
+// branchIssueKeyRule ensures a message references the issue found in a branch name
+type branchIssueKeyRule struct {
+	branch   string
+	projects []string
+}
+
+func (r branchIssueKeyRule) ID() string {
+	return RuleBranchIssueKey
+}
+
+func (r branchIssueKeyRule) Check(message *Message, commit *object.Commit) []Violation {
+	if violation := checkBranchIssueKey(message, r.branch, r.projects); violation != nil {
+		return []Violation{*violation}
+	}
+
+	return nil
+}
@@ -0,0 +1,281 @@
+package gommit
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildRules(t *testing.T) {
+	assert.Equal(t, []string{RuleTemplate, RuleAutosquash, RuleType, RuleScope, RuleTrailers, RuleDeny, RuleIssueKey}, ruleIDs(buildRules(&compiledMatchers{}, Options{})))
+	assert.Equal(t, []string{
+		RuleTemplate,
+		RuleSummaryLength,
+		RuleSummarySeparator,
+		RuleBodyLineLength,
+		RuleConventionalCommits,
+		RuleDuplicateSummary,
+		RuleAutosquash,
+		RuleType,
+		RuleScope,
+		RuleTrailers,
+		RuleDeny,
+		RuleIssueKey,
+	}, ruleIDs(buildRules(&compiledMatchers{}, Options{CheckSummaryLength: true, CheckSummarySeparator: true, CheckBodyLineLength: true, ConventionalCommits: true, CheckDuplicateSummaries: true})))
+}
+
+func TestBuildMergeRules(t *testing.T) {
+	custom := NewRule("custom", func(message *Message, commit *object.Commit) []Violation { return nil })
+
+	assert.Nil(t, buildMergeRules(&compiledMatchers{}, []Rule{custom}), "Must return no rules when no merge matcher is defined")
+	assert.Equal(t, []string{RuleTemplate, RuleDeny, "custom"}, ruleIDs(buildMergeRules(&compiledMatchers{merges: mustCompileMatcherSet(map[string]string{"branch": "^Merge branch"})}, []Rule{custom})))
+}
+
+func TestRuleSetRulesFor(t *testing.T) {
+	rules := []Rule{templateRule{}}
+	mergeRules := []Rule{denyRule{}}
+	botRules := []Rule{trailersRule{}}
+	commit := &object.Commit{ParentHashes: []plumbing.Hash{{1}}}
+	merge := &object.Commit{ParentHashes: []plumbing.Hash{{1}, {2}}}
+	octopus := &object.Commit{ParentHashes: []plumbing.Hash{{1}, {2}, {3}}}
+	bot := &object.Commit{Author: object.Signature{Name: "dependabot[bot]"}, ParentHashes: []plumbing.Hash{{1}, {2}}}
+	renovate := &object.Commit{Author: object.Signature{Name: "renovate[bot]"}}
+	exemptions := []exemptionRules{
+		{exemption: compiledExemption{name: "dependabot", identities: mustCompileMatcherSet(map[string]string{identityAuthorName: `^dependabot\[bot\]$`})}, rules: botRules},
+		{exemption: compiledExemption{name: "renovate", identities: mustCompileMatcherSet(map[string]string{identityAuthorName: `^renovate\[bot\]$`})}},
+	}
+
+	type scenario struct {
+		name      string
+		set       ruleSet
+		commit    *object.Commit
+		options   Options
+		exemption string
+		rules     []Rule
+	}
+
+	scenarios := []scenario{
+		{"Commit", ruleSet{rules: rules, mergeRules: mergeRules}, commit, Options{}, "", rules},
+		{"Merge commit", ruleSet{rules: rules, mergeRules: mergeRules}, merge, Options{}, "", mergeRules},
+		{"Octopus merge commit", ruleSet{rules: rules, mergeRules: mergeRules}, octopus, Options{}, "", mergeRules},
+		{"Merge commit without merge rules", ruleSet{rules: rules}, merge, Options{}, "", rules},
+		{"Exempted commit with rules", ruleSet{rules: rules, mergeRules: mergeRules, exemptions: exemptions}, bot, Options{}, "dependabot", botRules},
+		{"Exempted commit", ruleSet{rules: rules, exemptions: exemptions}, renovate, Options{}, "renovate", nil},
+		{"Excluded merge commit", ruleSet{rules: rules, mergeRules: mergeRules}, merge, Options{ExcludeMergeCommits: true}, "", nil},
+		{"Excluded exempted merge commit", ruleSet{rules: rules, exemptions: exemptions}, bot, Options{ExcludeMergeCommits: true}, "", nil},
+		{"Commit not matching exemptions", ruleSet{rules: rules, exemptions: exemptions}, commit, Options{}, "", rules},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			exemption, rules := s.set.rulesFor(s.commit, s.options)
+
+			assert.Equal(t, s.exemption, exemption)
+			assert.Equal(t, s.rules, rules)
+		})
+	}
+}
+
+func TestBuildRuleSet(t *testing.T) {
+	path := t.TempDir()
+	err := os.WriteFile(filepath.Join(path, "allowed_signers"), []byte(""), 0o644)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	custom := NewRule("custom", func(message *Message, commit *object.Commit) []Violation { return nil })
+	matchers := &compiledMatchers{
+		merges: mustCompileMatcherSet(map[string]string{"branch": "^Merge branch"}),
+		exemptions: []compiledExemption{
+			{name: "dependabot", identities: mustCompileMatcherSet(map[string]string{identityAuthorName: `^dependabot\[bot\]$`}), matchers: mustCompileMatcherSet(map[string]string{"bump": "^Bump"})},
+			{name: "renovate", identities: mustCompileMatcherSet(map[string]string{identityAuthorName: `^renovate\[bot\]$`})},
+		},
+	}
+	options := Options{AllowedSignersFile: "allowed_signers", AllowedEmailDomains: []string{"example.com"}}
+
+	set, err := buildRuleSet(matchers, options, []Rule{custom}, path)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{RuleTemplate, RuleAutosquash, RuleType, RuleScope, RuleTrailers, RuleDeny, RuleIssueKey, "custom", RuleSignature, RuleIdentity}, ruleIDs(set.rules))
+	assert.Equal(t, []string{RuleTemplate, RuleDeny, "custom", RuleSignature, RuleIdentity}, ruleIDs(set.mergeRules), "Must check merge commits against repository rules")
+	assert.Len(t, set.exemptions, 2)
+	assert.Equal(t, []string{RuleTemplate, RuleAutosquash, RuleType, RuleScope, RuleTrailers, RuleDeny, RuleIssueKey, "custom", RuleSignature, RuleIdentity}, ruleIDs(set.exemptions[0].rules), "Must check exempted commits against repository rules")
+	assert.Nil(t, set.exemptions[1].rules)
+	assert.Equal(t, []string{RuleSignature, RuleIdentity}, ruleIDs(set.trustRules))
+
+	merge := &object.Commit{
+		Message:      "Merge branch 'feature'\n",
+		Author:       object.Signature{Name: "John Doe", Email: "john.doe@other.org"},
+		Committer:    object.Signature{Name: "John Doe", Email: "john.doe@example.com"},
+		ParentHashes: []plumbing.Hash{{1}, {2}},
+	}
+	bot := &object.Commit{
+		Message:      "Bump a dependency\n",
+		Author:       object.Signature{Name: "dependabot[bot]", Email: "bot@example.com"},
+		Committer:    object.Signature{Name: "GitHub", Email: "noreply@github.com"},
+		ParentHashes: []plumbing.Hash{{1}},
+	}
+
+	assert.Equal(t, []string{ruleSignatureUnsigned, ruleIdentityEmail}, violationRuleIDs(analyzeCommit(merge, set, options).Violations), "Must report unsigned merge commits")
+	assert.Equal(t, []string{ruleSignatureUnsigned, ruleIdentityEmail}, violationRuleIDs(analyzeCommit(bot, set, options).Violations), "Must report unsigned exempted commits")
+
+	renovate := &object.Commit{
+		Message:      "whatever\n\nGommit-Skip: template (a reason)\n",
+		Author:       object.Signature{Name: "renovate[bot]", Email: "bot@example.com"},
+		Committer:    object.Signature{Name: "GitHub", Email: "noreply@github.com"},
+		ParentHashes: []plumbing.Hash{{1}},
+	}
+	m := analyzeCommit(renovate, set, options)
+
+	assert.Equal(t, "renovate", m.Exemption)
+	assert.Equal(t, []string{ruleSignatureUnsigned, ruleIdentityEmail}, violationRuleIDs(m.Violations), "Must report unsigned commits exempted from every check")
+
+	options.ExcludeMergeCommits = true
+
+	assert.Equal(t, []string{ruleSignatureUnsigned, ruleIdentityEmail}, violationRuleIDs(analyzeCommit(merge, set, options).Violations), "Must report unsigned excluded merge commits")
+}
+
+func violationRuleIDs(violations []Violation) []string {
+	s := []string{}
+
+	for _, violation := range violations {
+		s = append(s, violation.RuleID)
+	}
+
+	return s
+}
+
+func ruleIDs(rules []Rule) []string {
+	s := []string{}
+
+	for _, rule := range rules {
+		s = append(s, rule.ID())
+	}
+
+	return s
+}
+
+func TestExtractTypeAndScope(t *testing.T) {
+	matchers := mustCompileMatcherSet(map[string]string{
+		"a": "(?<type>feat)\\((?<scope>.*?)\\) : .*",
+		"b": "(?<type>\\w+) : .*",
+	})
+
+	commitType, scope := extractTypeAndScope(ParseMessage("feat(api) : a feature"), matchers, false)
+
+	assert.Equal(t, "feat", commitType)
+	assert.Equal(t, "api", scope)
+
+	commitType, scope = extractTypeAndScope(ParseMessage("fix : a fix"), matchers, false)
+
+	assert.Equal(t, "fix", commitType)
+	assert.Equal(t, "", scope)
+
+	commitType, scope = extractTypeAndScope(ParseMessage("fix(api): a fix"), matchers, true)
+
+	assert.Equal(t, "fix", commitType)
+	assert.Equal(t, "api", scope)
+
+	commitType, scope = extractTypeAndScope(ParseMessage("whatever"), matchers, false)
+
+	assert.Equal(t, "", commitType)
+	assert.Equal(t, "", scope)
+}
+
+func TestMatchMessageQueryWithCustomRules(t *testing.T) {
+	var commits []*object.Commit
+
+	q := MessageQuery{
+		Message:  "update(file) : fix",
+		Matchers: map[string]string{"simple": "(?:update|feat)\\(.*?\\) : .*"},
+		Options: Options{
+			Severities: map[string]Severity{"no-update": SeverityWarning},
+		},
+		Rules: []Rule{
+			NewRule("no-update", func(message *Message, commit *object.Commit) []Violation {
+				commits = append(commits, commit)
+
+				return []Violation{{Message: "update type is deprecated", Span: message.substringSpan(1, "update")}}
+			}),
+		},
+	}
+
+	m, err := MatchMessageQuery(q)
+
+	assert.NoError(t, err, "Must return no error")
+	assert.Equal(t, []*object.Commit{nil}, commits, "Must not provide a commit when checking a message")
+	assert.Equal(t, []Violation{
+		{RuleID: "no-update", Severity: SeverityWarning, Message: "update type is deprecated", Span: newSpan(1, 1, 7)},
+	}, m.Violations)
+	assert.Equal(t, "update(file) : fix", m.Context["message"], "Must contains original message")
+}
+
+func TestMatchRangeQueryWithCustomRules(t *testing.T) {
+	err := exec.Command("../features/repo.sh").Run()
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	q := RangeQuery{
+		Path:     "testing-repository/",
+		From:     "test~2",
+		To:       "test",
+		Matchers: map[string]string{"simple": "(?:update|feat)\\(.*?\\) : .*"},
+		Rules: []Rule{
+			NewRule("author", func(message *Message, commit *object.Commit) []Violation {
+				return []Violation{{Message: fmt.Sprintf("commit %s : %s", commit.ID().String(), message.Summary)}}
+			}),
+		},
+	}
+
+	m, err := MatchRangeQuery(q)
+
+	assert.NoError(t, err, "Must return no errors")
+	assert.Len(t, *m, 2, "Must return two items")
+
+	for _, matching := range *m {
+		assert.Equal(t, fmt.Sprintf("commit %s : %s", matching.Context["ID"], ParseMessage(matching.Context["message"]).Summary), matching.RuleViolations("author")[0].Message)
+	}
+}
+
+func TestMatchRangeQueryWithCustomRangeRules(t *testing.T) {
+	err := exec.Command("../features/repo.sh").Run()
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	var size int
+
+	q := RangeQuery{
+		Path:     "testing-repository/",
+		From:     "test~2",
+		To:       "test",
+		Matchers: map[string]string{"simple": "(?:update|feat)\\(.*?\\) : .*"},
+		Options: Options{
+			Severities: map[string]Severity{"oldest": SeverityInfo},
+		},
+		Rules: []Rule{
+			NewRangeRule("oldest", func(commits []*object.Commit) map[int][]Violation {
+				size = len(commits)
+
+				return map[int][]Violation{len(commits) - 1: {{Message: "oldest commit of range"}}}
+			}),
+		},
+	}
+
+	m, err := MatchRangeQuery(q)
+
+	assert.NoError(t, err, "Must return no errors")
+	assert.Equal(t, 2, size, "Must provide every commit of range")
+	assert.Len(t, *m, 1)
+	assert.Equal(t, []Violation{{RuleID: "oldest", Severity: SeverityInfo, Message: "oldest commit of range"}}, (*m)[0].Violations)
+	assert.Equal(t, "feat(file7) : new file 7\n\ncreate a new file 7\n", (*m)[0].Context["message"])
+	assert.NotEmpty(t, (*m)[0].Context["ID"])
+}
@@ -0,0 +1,103 @@
+package gommit
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Severity defines how a rule violation impacts a check result
+type Severity int
+
+// Severity levels, an error fails a check, a warning fails it only in strict mode
+// and an info never fails it
+const (
+	SeverityError Severity = iota
+	SeverityWarning
+	SeverityInfo
+)
+
+// Rule IDs used to configure severities
+const (
+	RuleTemplate            = "template"
+	RuleSummaryLength       = "summary-length"
+	RuleSummarySeparator    = "summary-separator"
+	RuleBodyLineLength      = "body-line-length"
+	RuleConventionalCommits = "conventional-commits"
+	RuleType                = "type"
+	RuleScope               = "scope"
+	RuleTrailers            = "trailers"
+	RuleDeny                = "deny"
+	RuleIssueKey            = "issue-key"
+	RuleBranchIssueKey      = "branch-issue-key"
+	RuleSuppression         = "suppression"
+	RuleMatchTimeout        = "match-timeout"
+	RuleAutosquash          = "autosquash"
+	RuleRevert              = "revert"
+	RuleIdentity            = "identity"
+	RuleSignature           = "signature"
+	RuleDuplicateSummary    = "duplicate-summary"
+)
+
+// String returns severity name
+func (s Severity) String() string {
+	switch s {
+	case SeverityWarning:
+		return "warning"
+	case SeverityInfo:
+		return "info"
+	default:
+		return "error"
+	}
+}
+
+// ParseSeverity converts a severity name to a Severity
+func ParseSeverity(name string) (Severity, error) {
+	switch name {
+	case "error":
+		return SeverityError, nil
+	case "warning":
+		return SeverityWarning, nil
+	case "info":
+		return SeverityInfo, nil
+	}
+
+	return SeverityError, fmt.Errorf(`severity "%s" doesn't exist, it must be error, warning or info`, name)
+}
+
+// MarshalText returns severity name
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// severityFor returns severity configured for a rule, an ID like "deny.wip" falls back
+// to the severity of "deny", defaultSeverity is returned when nothing is configured
+func severityFor(ID string, defaultSeverity Severity, options Options) Severity {
+	if severity, ok := options.Severities[ID]; ok {
+		return severity
+	}
+
+	if prefix, _, found := strings.Cut(ID, "."); found {
+		if severity, ok := options.Severities[prefix]; ok {
+			return severity
+		}
+	}
+
+	return defaultSeverity
+}
+
+// IsFailingMatching checks if a Matching struct contains a violation failing a check,
+// warnings fail a check only in strict mode
+func IsFailingMatching(matching *Matching, strict bool) bool {
+	for _, violation := range matching.Violations {
+		switch violation.Severity {
+		case SeverityError:
+			return true
+		case SeverityWarning:
+			if strict {
+				return true
+			}
+		}
+	}
+
+	return false
+}
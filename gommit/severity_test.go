@@ -0,0 +1,52 @@
+package gommit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSeverity(t *testing.T) {
+	for name, expected := range map[string]Severity{"error": SeverityError, "warning": SeverityWarning, "info": SeverityInfo} {
+		severity, err := ParseSeverity(name)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, severity)
+		assert.Equal(t, name, severity.String())
+	}
+
+	_, err := ParseSeverity("whatever")
+
+	assert.EqualError(t, err, `severity "whatever" doesn't exist, it must be error, warning or info`)
+}
+
+func TestSeverityFor(t *testing.T) {
+	options := Options{Severities: map[string]Severity{RuleSummaryLength: SeverityWarning, RuleDeny: SeverityInfo}}
+
+	assert.Equal(t, SeverityWarning, severityFor(RuleSummaryLength, SeverityError, options))
+	assert.Equal(t, SeverityError, severityFor(RuleTemplate, SeverityError, options), "Must default to given severity")
+	assert.Equal(t, SeverityWarning, severityFor("custom", SeverityWarning, options), "Must keep severity given by a rule when none is configured")
+	assert.Equal(t, SeverityInfo, severityFor(RuleDeny+".wip", SeverityError, options), "Must fallback on rule prefix")
+}
+
+func TestSeverityMarshalText(t *testing.T) {
+	text, err := SeverityWarning.MarshalText()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "warning", string(text))
+}
+
+func TestIsFailingMatching(t *testing.T) {
+	warning := &Matching{Violations: []Violation{{RuleID: RuleSummaryLength, Severity: SeverityWarning, Message: "a violation"}}}
+	info := &Matching{Violations: []Violation{{RuleID: RuleSummaryLength, Severity: SeverityInfo, Message: "a violation"}}}
+	failure := &Matching{Violations: []Violation{
+		{RuleID: RuleTemplate, Severity: SeverityError, Message: "a violation"},
+		{RuleID: RuleSummaryLength, Severity: SeverityWarning, Message: "a violation"},
+	}}
+
+	assert.False(t, IsFailingMatching(&Matching{}, true))
+	assert.False(t, IsFailingMatching(warning, false))
+	assert.True(t, IsFailingMatching(warning, true))
+	assert.False(t, IsFailingMatching(info, true))
+	assert.True(t, IsFailingMatching(failure, false))
+}
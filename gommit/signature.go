@@ -0,0 +1,180 @@
+package gommit
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	pgperrors "github.com/ProtonMail/go-crypto/openpgp/errors"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"golang.org/x/crypto/ssh"
+)
+
+// Rule IDs of signature checks, they're part of the signature rule
+const (
+	ruleSignatureUnsigned   = RuleSignature + ".unsigned"
+	ruleSignatureBad        = RuleSignature + ".bad"
+	ruleSignatureUnknownKey = RuleSignature + ".unknown-key"
+)
+
+// signatureRule ensures a commit is signed by a trusted key, PGP signatures are verified
+// against an armored keyring and SSH signatures against allowed signers, messages checked
+// on their own are not checked
+type signatureRule struct {
+	keyring        string
+	allowedSigners []allowedSigner
+}
+
+// newSignatureRule reads the keyring and the allowed signers file, relative paths
+// are resolved from repository path
+func newSignatureRule(repoPath string, keyringPath string, allowedSignersPath string) (signatureRule, error) {
+	rule := signatureRule{}
+
+	if keyringPath != "" {
+		content, err := os.ReadFile(resolvePath(repoPath, keyringPath))
+		if err != nil {
+			return rule, fmt.Errorf(`keyring "%s" can't be read : %w`, keyringPath, err)
+		}
+
+		if _, err := openpgp.ReadArmoredKeyRing(strings.NewReader(string(content))); err != nil {
+			return rule, fmt.Errorf(`keyring "%s" is not a valid armored keyring : %w`, keyringPath, err)
+		}
+
+		rule.keyring = string(content)
+	}
+
+	if allowedSignersPath != "" {
+		content, err := os.ReadFile(resolvePath(repoPath, allowedSignersPath))
+		if err != nil {
+			return rule, fmt.Errorf(`allowed signers file "%s" can't be read : %w`, allowedSignersPath, err)
+		}
+
+		if rule.allowedSigners, err = parseAllowedSigners(string(content)); err != nil {
+			return rule, fmt.Errorf(`allowed signers file "%s" : %w`, allowedSignersPath, err)
+		}
+	}
+
+	return rule, nil
+}
+
+// resolvePath resolves a relative path from repository path
+func resolvePath(repoPath string, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+
+	return filepath.Join(repoPath, path)
+}
+
+func (r signatureRule) ID() string {
+	return RuleSignature
+}
+
+func (r signatureRule) Check(message *Message, commit *object.Commit) []Violation {
+	if commit == nil {
+		return nil
+	}
+
+	if strings.TrimSpace(commit.PGPSignature) == "" {
+		return []Violation{{RuleID: ruleSignatureUnsigned, Message: "commit is not signed"}}
+	}
+
+	var violation *Violation
+
+	if isSSHSignature(commit.PGPSignature) {
+		violation = r.checkSSHSignature(commit)
+	} else {
+		violation = r.checkPGPSignature(commit)
+	}
+
+	if violation == nil {
+		return nil
+	}
+
+	return []Violation{*violation}
+}
+
+// checkPGPSignature verifies a PGP signature against the keyring
+func (r signatureRule) checkPGPSignature(commit *object.Commit) *Violation {
+	if r.keyring == "" {
+		return &Violation{RuleID: ruleSignatureUnknownKey, Message: "commit is signed with a PGP key but no keyring is defined"}
+	}
+
+	_, err := commit.Verify(r.keyring)
+
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, pgperrors.ErrUnknownIssuer):
+		return &Violation{RuleID: ruleSignatureUnknownKey, Message: fmt.Sprintf("commit is signed with PGP key %s which is not part of the keyring", pgpSignatureKeyID(commit.PGPSignature))}
+	default:
+		return &Violation{RuleID: ruleSignatureBad, Message: fmt.Sprintf("commit signature is not valid : %s", err)}
+	}
+}
+
+// checkSSHSignature verifies an SSH signature made by an allowed signer
+func (r signatureRule) checkSSHSignature(commit *object.Commit) *Violation {
+	signature, err := parseSSHSignature(commit.PGPSignature)
+	if err != nil {
+		return &Violation{RuleID: ruleSignatureBad, Message: fmt.Sprintf("commit signature is not valid : %s", err)}
+	}
+
+	if _, ok := findAllowedSigner(signature.publicKey, r.allowedSigners); !ok {
+		return &Violation{RuleID: ruleSignatureUnknownKey, Message: fmt.Sprintf("commit is signed with SSH key %s which is not an allowed signer", ssh.FingerprintSHA256(signature.publicKey))}
+	}
+
+	data, err := encodeWithoutSignature(commit)
+	if err != nil {
+		return &Violation{RuleID: ruleSignatureBad, Message: fmt.Sprintf("commit signature is not valid : %s", err)}
+	}
+
+	if err := signature.verify(data); err != nil {
+		return &Violation{RuleID: ruleSignatureBad, Message: fmt.Sprintf("commit signature is not valid : %s", err)}
+	}
+
+	return nil
+}
+
+// encodeWithoutSignature returns the commit object a signature is computed from
+func encodeWithoutSignature(commit *object.Commit) ([]byte, error) {
+	encoded := &plumbing.MemoryObject{}
+
+	if err := commit.EncodeWithoutSignature(encoded); err != nil {
+		return nil, err
+	}
+
+	reader, err := encoded.Reader()
+	if err != nil {
+		return nil, err
+	}
+
+	defer reader.Close()
+
+	return io.ReadAll(reader)
+}
+
+// pgpSignatureKeyID returns the ID of the key which produced an armored PGP signature
+func pgpSignatureKeyID(armored string) string {
+	block, err := armor.Decode(strings.NewReader(armored))
+	if err != nil {
+		return "unknown"
+	}
+
+	p, err := packet.Read(block.Body)
+	if err != nil {
+		return "unknown"
+	}
+
+	if signature, ok := p.(*packet.Signature); ok && signature.IssuerKeyId != nil {
+		return fmt.Sprintf("%016X", *signature.IssuerKeyId)
+	}
+
+	return "unknown"
+}
@@ -0,0 +1,126 @@
+package gommit
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAllowedSigners(t *testing.T) {
+	signers, err := parseAllowedSigners(`# team keys
+john@example.com ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIGmA8Ga0b4AOPRXmdSKSyMRsz9VjK+9WJ3xFZNmcUlxN john
+
+jane@example.com,*@example.org namespaces="git,file" ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIGmA8Ga0b4AOPRXmdSKSyMRsz9VjK+9WJ3xFZNmcUlxN
+*@example.com cert-authority ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIGmA8Ga0b4AOPRXmdSKSyMRsz9VjK+9WJ3xFZNmcUlxN
+`)
+
+	assert.NoError(t, err)
+	assert.Len(t, signers, 2, "Must ignore comments, empty lines and certificate authorities")
+	assert.Equal(t, "john@example.com", signers[0].principals)
+	assert.Empty(t, signers[0].namespaces)
+	assert.Equal(t, "jane@example.com,*@example.org", signers[1].principals)
+	assert.Equal(t, []string{"git", "file"}, signers[1].namespaces)
+
+	_, err = parseAllowedSigners("john@example.com ssh-ed25519 whatever\n")
+
+	assert.ErrorContains(t, err, "line 1 is not valid : ")
+}
+
+func TestFindAllowedSigner(t *testing.T) {
+	signers, err := parseAllowedSigners(`john@example.com namespaces="file" ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIGmA8Ga0b4AOPRXmdSKSyMRsz9VjK+9WJ3xFZNmcUlxN
+jane@example.com namespaces="git" ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIGmA8Ga0b4AOPRXmdSKSyMRsz9VjK+9WJ3xFZNmcUlxN
+`)
+	assert.NoError(t, err)
+
+	signer, ok := findAllowedSigner(signers[0].publicKey, signers)
+
+	assert.True(t, ok)
+	assert.Equal(t, "jane@example.com", signer.principals, "Must find a signer allowed in git namespace")
+
+	_, ok = findAllowedSigner(signers[0].publicKey, signers[:1])
+
+	assert.False(t, ok)
+}
+
+func TestParseSSHSignatureWithErrors(t *testing.T) {
+	type scenario struct {
+		signature string
+		err       string
+	}
+
+	scenarios := []scenario{
+		{"-----BEGIN PGP SIGNATURE-----\n-----END PGP SIGNATURE-----", "signature must start with -----BEGIN SSH SIGNATURE-----"},
+		{"-----BEGIN SSH SIGNATURE-----\nU1NIU0lH\n", "signature must end with -----END SSH SIGNATURE-----"},
+		{"-----BEGIN SSH SIGNATURE-----\nd2hhdGV2ZXI=\n-----END SSH SIGNATURE-----", "signature doesn't start with SSHSIG"},
+	}
+
+	for _, s := range scenarios {
+		_, err := parseSSHSignature(s.signature)
+
+		assert.EqualError(t, err, s.err)
+	}
+}
+
+func TestSignatureRuleWithAnUnsignedCommit(t *testing.T) {
+	assert.Equal(t, []Violation{{RuleID: "signature.unsigned", Message: "commit is not signed"}}, signatureRule{}.Check(ParseMessage("feat : a feature"), &object.Commit{}))
+	assert.Empty(t, signatureRule{}.Check(ParseMessage("feat : a feature"), nil), "Must not check a message on its own")
+}
+
+func TestNewSignatureRuleWithErrors(t *testing.T) {
+	_, err := newSignatureRule("testing-repository", "whatever.asc", "")
+
+	assert.ErrorContains(t, err, `keyring "whatever.asc" can't be read : `)
+
+	_, err = newSignatureRule("..", "go.mod", "")
+
+	assert.ErrorContains(t, err, `keyring "go.mod" is not a valid armored keyring : `)
+
+	_, err = newSignatureRule("testing-repository", "", "allowed_signers")
+
+	assert.ErrorContains(t, err, `allowed signers file "allowed_signers" can't be read : `)
+}
+
+func TestMatchRangeQueryWithSignatures(t *testing.T) {
+	for _, filename := range []string{"../features/repo.sh", "../features/signed-commits.sh"} {
+		err := exec.Command(filename).Run()
+		if err != nil {
+			logrus.Fatal(err)
+		}
+	}
+
+	q := RangeQuery{
+		Path:     "testing-repository/",
+		From:     "test~6",
+		To:       "test",
+		Matchers: map[string]string{"simple": "^feat\\(.*?\\) : .*"},
+		Options: Options{
+			SignatureKeyring:   ".git/keys/keyring.asc",
+			AllowedSignersFile: ".git/keys/allowed_signers",
+		},
+	}
+
+	m, err := MatchRangeQuery(q)
+
+	assert.NoError(t, err, "Must return no errors")
+	assert.Len(t, *m, 4)
+
+	messages := []string{}
+
+	for _, matching := range *m {
+		assert.Len(t, matching.Violations, 1)
+		messages = append(messages, matching.Context["message"])
+	}
+
+	assert.Equal(t, []string{"feat(file14) : new file 15\n", "feat(file13) : new file 13\n", "feat(file11) : new file 11\n", "feat(file9) : new file 9\n"}, messages, "Must accept commits signed by trusted keys")
+
+	assert.Equal(t, "signature.bad", (*m)[0].Violations[0].RuleID)
+	assert.Regexp(t, `^commit signature is not valid : `, (*m)[0].Violations[0].Message)
+	assert.Equal(t, "signature.unknown-key", (*m)[1].Violations[0].RuleID)
+	assert.Regexp(t, `^commit is signed with SSH key SHA256:\S+ which is not an allowed signer$`, (*m)[1].Violations[0].Message)
+	assert.Equal(t, "signature.unknown-key", (*m)[2].Violations[0].RuleID)
+	assert.Regexp(t, `^commit is signed with PGP key [0-9A-F]{16} which is not part of the keyring$`, (*m)[2].Violations[0].Message)
+	assert.Equal(t, []Violation{{RuleID: "signature.unsigned", Message: "commit is not signed"}}, (*m)[3].Violations)
+}
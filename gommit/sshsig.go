@@ -0,0 +1,196 @@
+package gommit
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"slices"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// sshSignatureMagic starts a signature blob and the data it signs
+const sshSignatureMagic = "SSHSIG"
+
+// sshSignatureNamespace is the namespace git uses to sign commits
+const sshSignatureNamespace = "git"
+
+// Armor lines surrounding an SSH signature
+const (
+	sshSignatureBegin = "-----BEGIN SSH SIGNATURE-----"
+	sshSignatureEnd   = "-----END SSH SIGNATURE-----"
+)
+
+// sshSignatureBlob is the wire format of a signature produced by ssh-keygen -Y sign
+type sshSignatureBlob struct {
+	Version       uint32
+	PublicKey     []byte
+	Namespace     string
+	Reserved      string
+	HashAlgorithm string
+	Signature     []byte
+}
+
+// sshSignedData is the data an SSH signature is computed from
+type sshSignedData struct {
+	Namespace     string
+	Reserved      string
+	HashAlgorithm string
+	Hash          []byte
+}
+
+// sshSignature is a parsed SSH signature
+type sshSignature struct {
+	publicKey     ssh.PublicKey
+	namespace     string
+	hashAlgorithm string
+	signature     *ssh.Signature
+}
+
+// allowedSigner is an entry of an allowed signers file like the one used by git
+// with gpg.ssh.allowedSignersFile, namespaces are empty when any namespace is allowed
+type allowedSigner struct {
+	principals string
+	publicKey  ssh.PublicKey
+	namespaces []string
+}
+
+// isSSHSignature returns true if an armored signature is an SSH signature
+func isSSHSignature(armored string) bool {
+	return strings.HasPrefix(strings.TrimSpace(armored), sshSignatureBegin)
+}
+
+// parseSSHSignature parses an armored SSH signature
+func parseSSHSignature(armored string) (*sshSignature, error) {
+	content, ok := strings.CutPrefix(strings.TrimSpace(armored), sshSignatureBegin)
+	if !ok {
+		return nil, errors.New("signature must start with " + sshSignatureBegin)
+	}
+
+	content, ok = strings.CutSuffix(content, sshSignatureEnd)
+	if !ok {
+		return nil, errors.New("signature must end with " + sshSignatureEnd)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(content), ""))
+	if err != nil {
+		return nil, err
+	}
+
+	data, ok = bytes.CutPrefix(data, []byte(sshSignatureMagic))
+	if !ok {
+		return nil, errors.New("signature doesn't start with " + sshSignatureMagic)
+	}
+
+	blob := sshSignatureBlob{}
+
+	if err := ssh.Unmarshal(data, &blob); err != nil {
+		return nil, err
+	}
+
+	if blob.Version != 1 {
+		return nil, fmt.Errorf("signature version %d is not supported", blob.Version)
+	}
+
+	publicKey, err := ssh.ParsePublicKey(blob.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	signature := ssh.Signature{}
+
+	if err := ssh.Unmarshal(blob.Signature, &signature); err != nil {
+		return nil, err
+	}
+
+	return &sshSignature{publicKey: publicKey, namespace: blob.Namespace, hashAlgorithm: blob.HashAlgorithm, signature: &signature}, nil
+}
+
+// verify ensures signature was produced by its public key over data in git namespace
+func (s *sshSignature) verify(data []byte) error {
+	if s.namespace != sshSignatureNamespace {
+		return fmt.Errorf(`signature namespace "%s" must be "%s"`, s.namespace, sshSignatureNamespace)
+	}
+
+	var h hash.Hash
+
+	switch s.hashAlgorithm {
+	case "sha256":
+		h = sha256.New()
+	case "sha512":
+		h = sha512.New()
+	default:
+		return fmt.Errorf(`hash algorithm "%s" is not supported`, s.hashAlgorithm)
+	}
+
+	h.Write(data)
+
+	signed := append([]byte(sshSignatureMagic), ssh.Marshal(sshSignedData{
+		Namespace:     s.namespace,
+		HashAlgorithm: s.hashAlgorithm,
+		Hash:          h.Sum(nil),
+	})...)
+
+	return s.publicKey.Verify(signed, s.signature)
+}
+
+// parseAllowedSigners parses an allowed signers file, every line is made of principals, options
+// and a public key, certificate authorities are not supported and their lines are ignored
+func parseAllowedSigners(content string) ([]allowedSigner, error) {
+	signers := []allowedSigner{}
+
+	for i, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		principals, rest, _ := strings.Cut(line, " ")
+
+		publicKey, _, options, _, err := ssh.ParseAuthorizedKey([]byte(rest))
+		if err != nil {
+			return nil, fmt.Errorf("line %d is not valid : %w", i+1, err)
+		}
+
+		signer := allowedSigner{principals: principals, publicKey: publicKey}
+		certificateAuthority := false
+
+		for _, option := range options {
+			name, value, _ := strings.Cut(option, "=")
+
+			switch strings.ToLower(name) {
+			case "cert-authority":
+				certificateAuthority = true
+			case "namespaces":
+				signer.namespaces = strings.Split(strings.Trim(value, `"`), ",")
+			}
+		}
+
+		if !certificateAuthority {
+			signers = append(signers, signer)
+		}
+	}
+
+	return signers, nil
+}
+
+// findAllowedSigner returns the signer allowed to sign in git namespace with a public key if any
+func findAllowedSigner(publicKey ssh.PublicKey, signers []allowedSigner) (allowedSigner, bool) {
+	for _, signer := range signers {
+		if !bytes.Equal(signer.publicKey.Marshal(), publicKey.Marshal()) {
+			continue
+		}
+
+		if len(signer.namespaces) == 0 || slices.Contains(signer.namespaces, sshSignatureNamespace) {
+			return signer, true
+		}
+	}
+
+	return allowedSigner{}, false
+}
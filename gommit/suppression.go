@@ -0,0 +1,117 @@
+package gommit
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// skipTrailer is the trailer key used to waive a rule for a commit
+const skipTrailer = "Gommit-Skip"
+
+// suppressionRegexp matches a skip trailer value like "summary-length (imported history)"
+var suppressionRegexp = regexp.MustCompile(`^(\S+)\s*\((.*)\)$`)
+
+// unsuppressibleRules are rules a commit can't waive by itself, as they ensure commits
+// come from trusted people whatever their message is, Signed-off-by checks certify it (DCO)
+var unsuppressibleRules = []string{RuleIdentity, RuleSignature, ruleSignedOffBy}
+
+// Suppression waives violations of a rule, Reason is mandatory to keep waivers auditable
+type Suppression struct {
+	RuleID string
+	Reason string
+	Line   int
+}
+
+// suppresses returns true if a violation produced by rule ID is waived,
+// suppressing "deny" waives every deny matcher
+func (s Suppression) suppresses(ID string) bool {
+	return ID == s.RuleID || strings.HasPrefix(ID, s.RuleID+".")
+}
+
+// parseSuppressions extracts suppressions defined in Gommit-Skip trailers of a message,
+// a trailer without a reason, targeting an unknown rule or a rule which can't be waived
+// produces a violation
+func parseSuppressions(message *Message, rules []Rule) ([]Suppression, []Violation) {
+	suppressions := []Suppression{}
+	violations := []Violation{}
+
+	for _, trailer := range message.Trailers {
+		if !strings.EqualFold(trailer.Key, skipTrailer) {
+			continue
+		}
+
+		matches := suppressionRegexp.FindStringSubmatch(strings.TrimSpace(trailer.Value))
+
+		if matches == nil || strings.TrimSpace(matches[2]) == "" {
+			violations = append(violations, Violation{
+				Message: fmt.Sprintf(`"%s" trailer must be written "%s: <rule> (<reason>)", a reason is mandatory`, skipTrailer, skipTrailer),
+				Span:    message.lineSpan(trailer.Line),
+			})
+
+			continue
+		}
+
+		suppression := Suppression{RuleID: matches[1], Reason: strings.TrimSpace(matches[2]), Line: trailer.Line}
+
+		if !isKnownRule(suppression.RuleID, rules) {
+			violations = append(violations, Violation{
+				Message: fmt.Sprintf(`"%s" trailer references unknown rule "%s"`, skipTrailer, suppression.RuleID),
+				Span:    message.substringSpan(trailer.Line, suppression.RuleID),
+			})
+
+			continue
+		}
+
+		if !isSuppressibleRule(suppression.RuleID) {
+			violations = append(violations, Violation{
+				Message: fmt.Sprintf(`"%s" trailer can't waive rule "%s"`, skipTrailer, suppression.RuleID),
+				Span:    message.substringSpan(trailer.Line, suppression.RuleID),
+			})
+
+			continue
+		}
+
+		suppressions = append(suppressions, suppression)
+	}
+
+	return suppressions, violations
+}
+
+// isKnownRule returns true if ID identifies one of the rules, or a violation produced by one of them like "deny.wip"
+func isKnownRule(ID string, rules []Rule) bool {
+	for _, rule := range rules {
+		if ID == rule.ID() || strings.HasPrefix(ID, rule.ID()+".") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isSuppressibleRule returns true if a commit can waive a rule, or a violation produced by a rule like "signature.unsigned"
+func isSuppressibleRule(ID string) bool {
+	for _, rule := range unsuppressibleRules {
+		if ID == rule || strings.HasPrefix(ID, rule+".") {
+			return false
+		}
+	}
+
+	return true
+}
+
+// findSuppression returns the suppression waiving a violation if any, violations of
+// rules which can't be waived are never waived even by a suppression of their parent rule
+func findSuppression(violation Violation, suppressions []Suppression) (Suppression, bool) {
+	if !isSuppressibleRule(violation.RuleID) {
+		return Suppression{}, false
+	}
+
+	for _, suppression := range suppressions {
+		if suppression.suppresses(violation.RuleID) {
+			return suppression, true
+		}
+	}
+
+	return Suppression{}, false
+}
@@ -0,0 +1,104 @@
+package gommit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSuppressions(t *testing.T) {
+	rules := []Rule{summaryLengthRule{}, denyRule{}}
+
+	suppressions, violations := parseSuppressions(ParseMessage("summary\n\nGommit-Skip: summary-length (imported history)\ngommit-skip: deny.wip (work in progress is kept on purpose)\n"), rules)
+
+	assert.Empty(t, violations)
+	assert.Equal(t, []Suppression{
+		{RuleID: RuleSummaryLength, Reason: "imported history", Line: 3},
+		{RuleID: "deny.wip", Reason: "work in progress is kept on purpose", Line: 4},
+	}, suppressions)
+
+	suppressions, violations = parseSuppressions(ParseMessage("summary\n\nGommit-Skip: summary-length\nGommit-Skip: summary-length ( )\nGommit-Skip: whatever (a reason)\n"), rules)
+
+	assert.Empty(t, suppressions)
+	assert.Equal(t, []Violation{
+		{Message: `"Gommit-Skip" trailer must be written "Gommit-Skip: <rule> (<reason>)", a reason is mandatory`, Span: newSpan(3, 1, 28)},
+		{Message: `"Gommit-Skip" trailer must be written "Gommit-Skip: <rule> (<reason>)", a reason is mandatory`, Span: newSpan(4, 1, 32)},
+		{Message: `"Gommit-Skip" trailer references unknown rule "whatever"`, Span: newSpan(5, 14, 22)},
+	}, violations)
+}
+
+func TestAnalyzeMessageWithUnsuppressibleRules(t *testing.T) {
+	commit := &object.Commit{
+		Message:   "update(file) : a change\n\nGommit-Skip: signature (trust me)\nGommit-Skip: identity.name (a reason)\n",
+		Author:    object.Signature{Name: "root", Email: "root@example.com", When: time.Now()},
+		Committer: object.Signature{Name: "John Doe", Email: "john.doe@example.com", When: time.Now()},
+	}
+	rules := []Rule{signatureRule{}, identityRule{placeholders: []string{"root"}, now: time.Now()}}
+
+	m := analyzeMessage(commit.Message, commit, rules, Options{})
+
+	assert.Empty(t, m.Suppressed, "Must not waive signature and identity rules")
+	assert.Equal(t, []Violation{
+		{RuleID: RuleSuppression, Message: `"Gommit-Skip" trailer can't waive rule "signature"`, Span: newSpan(3, 14, 23)},
+		{RuleID: RuleSuppression, Message: `"Gommit-Skip" trailer can't waive rule "identity.name"`, Span: newSpan(4, 14, 27)},
+		{RuleID: ruleSignatureUnsigned, Message: "commit is not signed"},
+		{RuleID: ruleIdentityName, Message: `author name "root" is a placeholder, configure user.name in git`},
+	}, m.Violations)
+}
+
+func TestMatchMessageQueryWithSuppressedSignedOffBy(t *testing.T) {
+	q := MessageQuery{
+		Message:  "update(file) : a change\n\nGommit-Skip: trailers (whatever)\nGommit-Skip: trailers.signed-off-by (trust me)\n",
+		Matchers: map[string]string{"simple": "(?:update|feat)\\(.*?\\) : .*"},
+		Options: Options{
+			CheckSignedOffBy: true,
+			RequiredTrailers: []string{"Refs"},
+		},
+	}
+
+	m, err := MatchMessageQuery(q)
+
+	assert.NoError(t, err, "Must return no error")
+	assert.Equal(t, []Violation{
+		{RuleID: RuleSuppression, Message: `"Gommit-Skip" trailer can't waive rule "trailers.signed-off-by"`, Span: newSpan(4, 14, 36)},
+		{RuleID: ruleSignedOffBy, Message: `required trailer "Signed-off-by" is missing`},
+	}, m.Violations, "Must not waive Signed-off-by checks")
+	assert.Equal(t, []Violation{
+		{RuleID: RuleTrailers, Message: `required trailer "Refs" is missing`, SuppressionReason: "whatever"},
+	}, m.Suppressed, "Must waive other trailer checks")
+}
+
+func TestMatchMessageQueryWithSuppressions(t *testing.T) {
+	q := MessageQuery{
+		Message:  "update(file) : fix WIP\n\nGommit-Skip: deny (imported history)\n",
+		Matchers: map[string]string{"simple": "(?:update|feat)\\(.*?\\) : .*"},
+		Options: Options{
+			DenyMatchers: map[string]string{"wip": "WIP"},
+		},
+		Rules: []Rule{
+			NewRule("custom", func(message *Message, commit *object.Commit) []Violation {
+				return []Violation{{Message: "a custom violation"}}
+			}),
+		},
+	}
+
+	m, err := MatchMessageQuery(q)
+
+	assert.NoError(t, err, "Must return no error")
+	assert.Equal(t, []Violation{{RuleID: "custom", Message: "a custom violation"}}, m.Violations, "Must keep violations of rules not waived")
+	assert.Equal(t, []Violation{
+		{RuleID: "deny.wip", Message: `deny rule "wip" matched "WIP"`, Span: newSpan(1, 20, 23), SuppressionReason: "imported history"},
+	}, m.Suppressed)
+	assert.False(t, IsFailingMatching(&Matching{Suppressed: m.Suppressed}, true), "Must not fail on suppressed violations")
+
+	q.Message = "update(file) : fix WIP\n\nGommit-Skip: custom (a reason)\n"
+	q.Options.Severities = map[string]Severity{RuleSuppression: SeverityWarning}
+
+	m, err = MatchMessageQuery(q)
+
+	assert.NoError(t, err, "Must return no error")
+	assert.Equal(t, []string{`deny rule "wip" matched "WIP"`}, violationMessages(m.Violations))
+	assert.Equal(t, []string{"a custom violation"}, violationMessages(m.Suppressed), "Must waive custom rules")
+}
@@ -0,0 +1,101 @@
+package gommit
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// signedOffByTrailer is the trailer key used to certify a commit origin (DCO)
+const signedOffByTrailer = "Signed-off-by"
+
+// ruleSignedOffBy identifies Signed-off-by checks, they're part of the trailers rule
+const ruleSignedOffBy = RuleTrailers + ".signed-off-by"
+
+// TrailerValues returns values of all trailers matching key, comparison is case insensitive
+func (m *Message) TrailerValues(key string) []string {
+	values := []string{}
+
+	for _, trailer := range m.Trailers {
+		if strings.EqualFold(trailer.Key, key) {
+			values = append(values, trailer.Value)
+		}
+	}
+
+	return values
+}
+
+// checkTrailers ensures required trailers are present, forbidden ones are absent
+// and trailer values match patterns defined for their key, patterns are named after
+// the key they apply to, a pattern timing out produces a timeout violation
+func checkTrailers(message *Message, options Options, patterns matcherSet, timeout time.Duration) []Violation {
+	violations := []Violation{}
+
+	for _, key := range options.RequiredTrailers {
+		if len(message.TrailerValues(key)) == 0 {
+			violations = append(violations, Violation{Message: fmt.Sprintf(`required trailer "%s" is missing`, key)})
+		}
+	}
+
+	if options.CheckSignedOffBy && len(message.TrailerValues(signedOffByTrailer)) == 0 {
+		violations = append(violations, Violation{RuleID: ruleSignedOffBy, Message: fmt.Sprintf(`required trailer "%s" is missing`, signedOffByTrailer)})
+	}
+
+	for _, trailer := range message.Trailers {
+		for _, key := range options.ForbiddenTrailers {
+			if strings.EqualFold(trailer.Key, key) {
+				violations = append(violations, Violation{Message: fmt.Sprintf(`forbidden trailer "%s" found`, trailer.Key), Span: message.lineSpan(trailer.Line)})
+			}
+		}
+
+		for _, pattern := range patterns {
+			if !strings.EqualFold(trailer.Key, pattern.name) {
+				continue
+			}
+
+			ok, err := pattern.match(trailer.Value)
+			if err != nil {
+				violations = append(violations, matchTimeoutViolation("trailers.patterns."+pattern.name, timeout))
+
+				continue
+			}
+
+			if !ok {
+				violations = append(violations, Violation{
+					Message: fmt.Sprintf(`trailer "%s" value "%s" doesn't match pattern "%s"`, trailer.Key, trailer.Value, pattern.pattern),
+					Span:    message.lineSpan(trailer.Line),
+				})
+			}
+		}
+	}
+
+	return violations
+}
+
+// checkSignedOffByAuthor ensures one of the Signed-off-by trailers certifies the commit author
+func checkSignedOffByAuthor(message *Message, commit *object.Commit) *Violation {
+	author := fmt.Sprintf("%s <%s>", commit.Author.Name, commit.Author.Email)
+	span := Span{}
+
+	for _, trailer := range message.Trailers {
+		if !strings.EqualFold(trailer.Key, signedOffByTrailer) {
+			continue
+		}
+
+		if trailer.Value == author {
+			return nil
+		}
+
+		if span.IsZero() {
+			span = message.lineSpan(trailer.Line)
+		}
+	}
+
+	if span.IsZero() {
+		return nil
+	}
+
+	return &Violation{RuleID: ruleSignedOffBy, Message: fmt.Sprintf(`no "%s" trailer matches commit author "%s"`, signedOffByTrailer, author), Span: span, Suggestion: signedOffByTrailer + ": " + author}
+}
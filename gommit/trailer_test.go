@@ -0,0 +1,82 @@
+package gommit
+
+import (
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrailerValues(t *testing.T) {
+	m := ParseMessage("summary\n\nSigned-off-by: John Doe <john@example.com>\nsigned-off-by: Jane Doe <jane@example.com>\nRefs: PROJ-123\n")
+
+	assert.Equal(t, []string{"John Doe <john@example.com>", "Jane Doe <jane@example.com>"}, m.TrailerValues("Signed-off-by"))
+	assert.Equal(t, []string{"PROJ-123"}, m.TrailerValues("refs"))
+	assert.Empty(t, m.TrailerValues("Reviewed-by"))
+}
+
+func TestCheckTrailers(t *testing.T) {
+	type scenario struct {
+		name    string
+		message string
+		options Options
+		test    func([]Violation)
+	}
+
+	scenarios := []scenario{
+		{
+			"No trailer rules",
+			"summary\n",
+			Options{},
+			func(violations []Violation) {
+				assert.Empty(t, violations)
+			},
+		},
+		{
+			"Missing required trailers",
+			"summary\n\nReviewed-by: John Doe <john@example.com>\n",
+			Options{CheckSignedOffBy: true, RequiredTrailers: []string{"reviewed-by", "Refs"}},
+			func(violations []Violation) {
+				assert.Equal(t, []Violation{
+					{Message: `required trailer "Refs" is missing`},
+					{RuleID: ruleSignedOffBy, Message: `required trailer "Signed-off-by" is missing`},
+				}, violations)
+			},
+		},
+		{
+			"Forbidden trailer",
+			"summary\n\nbody\n\nChange-Id: I1234\n",
+			Options{ForbiddenTrailers: []string{"change-id"}},
+			func(violations []Violation) {
+				assert.Equal(t, []Violation{{Message: `forbidden trailer "Change-Id" found`, Span: newSpan(5, 1, 17)}}, violations)
+			},
+		},
+		{
+			"Trailer values matching patterns",
+			"summary\n\nRefs: PROJ-123\nRefs: 123\nReviewed-by: John Doe\n",
+			Options{TrailerPatterns: map[string]string{"refs": "^[A-Z]+-\\d+$"}},
+			func(violations []Violation) {
+				assert.Equal(t, []Violation{{Message: `trailer "Refs" value "123" doesn't match pattern "^[A-Z]+-\d+$"`, Span: newSpan(4, 1, 10)}}, violations)
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			s.test(checkTrailers(ParseMessage(s.message), s.options, mustCompileMatcherSet(s.options.TrailerPatterns), 0))
+		})
+	}
+}
+
+func TestCheckSignedOffByAuthor(t *testing.T) {
+	commit := &object.Commit{Author: object.Signature{Name: "John Doe", Email: "john@example.com"}}
+
+	assert.Nil(t, checkSignedOffByAuthor(ParseMessage("summary\n"), commit), "Must not check anything when there is no trailer")
+	assert.Nil(t, checkSignedOffByAuthor(ParseMessage("summary\n\nSigned-off-by: Jane Doe <jane@example.com>\nSigned-off-by: John Doe <john@example.com>\n"), commit))
+	assert.Equal(t, &Violation{
+		RuleID:     ruleSignedOffBy,
+		Message:    `no "Signed-off-by" trailer matches commit author "John Doe <john@example.com>"`,
+		Span:       newSpan(3, 1, 43),
+		Suggestion: "Signed-off-by: John Doe <john@example.com>",
+	}, checkSignedOffByAuthor(ParseMessage("summary\n\nSigned-off-by: Jane Doe <jane@example.com>\n"), commit))
+}
@@ -0,0 +1,98 @@
+package gommit
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Position is a location in a commit message, line and column start from 1,
+// column is counted in characters
+type Position struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// Span is a range in a commit message, End is exclusive,
+// a zero Span means a violation is not bound to a location
+type Span struct {
+	Start Position `json:"start"`
+	End   Position `json:"end"`
+}
+
+// Violation represents a rule violation, RuleID and Severity are set from the rule producing
+// the violation if they are not provided, Suggestion is an optional replacement value,
+// SuppressionReason is the reason given by a Gommit-Skip trailer waiving the violation
+type Violation struct {
+	RuleID            string   `json:"ruleId"`
+	Severity          Severity `json:"severity"`
+	Message           string   `json:"message"`
+	Span              Span     `json:"span"`
+	Suggestion        string   `json:"suggestion,omitempty"`
+	SuppressionReason string   `json:"suppressionReason,omitempty"`
+}
+
+// IsZero returns true if span is not bound to a location
+func (s Span) IsZero() bool {
+	return s == Span{}
+}
+
+// String returns a human readable span
+func (s Span) String() string {
+	if s.IsZero() {
+		return ""
+	}
+
+	if s.Start.Line == s.End.Line {
+		return fmt.Sprintf("line %d, column %d-%d", s.Start.Line, s.Start.Column, s.End.Column)
+	}
+
+	return fmt.Sprintf("line %d, column %d to line %d, column %d", s.Start.Line, s.Start.Column, s.End.Line, s.End.Column)
+}
+
+// newSpan creates a span on a single line between two columns
+func newSpan(line int, startColumn int, endColumn int) Span {
+	return Span{Start: Position{Line: line, Column: startColumn}, End: Position{Line: line, Column: endColumn}}
+}
+
+// shift moves a span starting on line by columns characters
+func (s Span) shift(line int, columns int) Span {
+	if s.IsZero() || s.Start.Line != line || columns == 0 {
+		return s
+	}
+
+	s.Start.Column += columns
+
+	if s.End.Line == line {
+		s.End.Column += columns
+	}
+
+	return s
+}
+
+// lineSpan creates a span covering a whole line of a message
+func (m *Message) lineSpan(line int) Span {
+	if line < 1 || line > len(m.Lines) {
+		return Span{}
+	}
+
+	return newSpan(line, 1, utf8.RuneCountInString(m.Lines[line-1])+1)
+}
+
+// substringSpan creates a span covering the first occurrence of a substring in a line
+// of a message, the whole line is covered if substring can't be found
+func (m *Message) substringSpan(line int, substring string) Span {
+	if line < 1 || line > len(m.Lines) {
+		return Span{}
+	}
+
+	i := strings.Index(m.Lines[line-1], substring)
+
+	if substring == "" || i < 0 {
+		return m.lineSpan(line)
+	}
+
+	start := utf8.RuneCountInString(m.Lines[line-1][:i]) + 1
+
+	return newSpan(line, start, start+utf8.RuneCountInString(substring))
+}
@@ -0,0 +1,40 @@
+package gommit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpanString(t *testing.T) {
+	assert.Equal(t, "", Span{}.String())
+	assert.True(t, Span{}.IsZero())
+	assert.Equal(t, "line 1, column 3-8", newSpan(1, 3, 8).String())
+	assert.False(t, newSpan(1, 3, 8).IsZero())
+	assert.Equal(t, "line 1, column 3 to line 2, column 4", Span{Start: Position{Line: 1, Column: 3}, End: Position{Line: 2, Column: 4}}.String())
+}
+
+func TestMessageLineSpan(t *testing.T) {
+	message := ParseMessage("résumé\n\nbody\n")
+
+	assert.Equal(t, newSpan(1, 1, 7), message.lineSpan(1), "Must count columns in characters")
+	assert.Equal(t, newSpan(3, 1, 5), message.lineSpan(3))
+	assert.Equal(t, Span{}, message.lineSpan(0))
+	assert.Equal(t, Span{}, message.lineSpan(10))
+}
+
+func TestMessageSubstringSpan(t *testing.T) {
+	message := ParseMessage("feat(résumé) : add a feature\n")
+
+	assert.Equal(t, newSpan(1, 6, 12), message.substringSpan(1, "résumé"))
+	assert.Equal(t, newSpan(1, 16, 19), message.substringSpan(1, "add"), "Must count columns in characters")
+	assert.Equal(t, newSpan(1, 1, 29), message.substringSpan(1, "whatever"), "Must cover whole line when substring can't be found")
+	assert.Equal(t, Span{}, message.substringSpan(2, "add"))
+}
+
+func TestSpanShift(t *testing.T) {
+	assert.Equal(t, newSpan(1, 8, 12), newSpan(1, 1, 5).shift(1, 7))
+	assert.Equal(t, Span{Start: Position{Line: 1, Column: 8}, End: Position{Line: 2, Column: 5}}, Span{Start: Position{Line: 1, Column: 1}, End: Position{Line: 2, Column: 5}}.shift(1, 7))
+	assert.Equal(t, newSpan(2, 1, 5), newSpan(2, 1, 5).shift(1, 7), "Must not move a span starting on another line")
+	assert.Equal(t, Span{}, Span{}.shift(1, 7))
+}
@@ -0,0 +1,27 @@
+package reference
+
+import (
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+)
+
+// defaultCommentChar is the character git uses to start comment lines
+// when core.commentChar is not defined
+const defaultCommentChar = "#"
+
+// FetchCommentChar retrieves core.commentChar from repository configuration, global
+// configuration is used if repository doesn't define it, "auto" falls back to "#"
+func FetchCommentChar(repo *git.Repository) (string, error) {
+	cfg, err := repo.ConfigScoped(config.GlobalScope)
+	if err != nil {
+		return "", err
+	}
+
+	commentChar := cfg.Raw.Section("core").Option("commentChar")
+
+	if commentChar == "" || commentChar == "auto" {
+		return defaultCommentChar, nil
+	}
+
+	return commentChar, nil
+}
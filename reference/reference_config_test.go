@@ -0,0 +1,32 @@
+package reference
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFetchCommentChar(t *testing.T) {
+	cmd := exec.Command("git", "config", "core.commentChar", ";")
+	cmd.Dir = gitRepositoryPath
+	assert.NoError(t, cmd.Run())
+
+	commentChar, err := FetchCommentChar(repo)
+
+	assert.NoError(t, err, "Must return no errors")
+	assert.Equal(t, ";", commentChar, "Must return comment char defined in repository")
+
+	cmd = exec.Command("git", "config", "core.commentChar", "auto")
+	cmd.Dir = gitRepositoryPath
+	assert.NoError(t, cmd.Run())
+
+	commentChar, err = FetchCommentChar(repo)
+
+	assert.NoError(t, err, "Must return no errors")
+	assert.Equal(t, "#", commentChar, "Must fallback to default comment char")
+
+	cmd = exec.Command("git", "config", "--unset", "core.commentChar")
+	cmd.Dir = gitRepositoryPath
+	assert.NoError(t, cmd.Run())
+}
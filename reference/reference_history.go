@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"regexp"
+	"time"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
@@ -83,6 +84,21 @@ func FetchCommitByID(repo *git.Repository, ID string) (*object.Commit, error) {
 	return repo.CommitObject(hash)
 }
 
+// FetchCurrentBranch retrieves the branch name HEAD points to, an empty string is returned
+// if HEAD is detached. HEAD is not resolved so the branch of a repository without commits is found
+func FetchCurrentBranch(repo *git.Repository) (string, error) {
+	head, err := repo.Storer.Reference(plumbing.HEAD)
+	if err != nil {
+		return "", err
+	}
+
+	if head.Type() != plumbing.SymbolicReference || !head.Target().IsBranch() {
+		return "", nil
+	}
+
+	return head.Target().Short(), nil
+}
+
 // resolveRef gives hash commit for a given string reference
 func resolveRef(refCommit string, repository *git.Repository) (*object.Commit, error) {
 	hash, err := repository.ResolveRevision(plumbing.Revision(refCommit))
@@ -183,3 +199,38 @@ func findDiffCommits(commit *object.Commit, exclusionList *map[string]bool) (*[]
 
 	return &commits, nil
 }
+
+// CommitExists returns true if a commit identified by a full or an abbreviated ID exists in repository
+func CommitExists(repo *git.Repository, ID string) (bool, error) {
+	hash, err := repo.ResolveRevision(plumbing.Revision(ID))
+	if errors.Is(err, plumbing.ErrReferenceNotFound) || errors.Is(err, plumbing.ErrObjectNotFound) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return !hash.IsZero(), nil
+}
+
+// FetchFirstCommitDate retrieves the author date of the oldest root commit of repository,
+// a zero time is returned if repository doesn't contain any commit
+func FetchFirstCommitDate(repo *git.Repository) (time.Time, error) {
+	commits, err := repo.CommitObjects()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var first time.Time
+
+	err = commits.ForEach(func(c *object.Commit) error {
+		if c.NumParents() == 0 && (first.IsZero() || c.Author.When.Before(first)) {
+			first = c.Author.When
+		}
+
+		return nil
+	})
+
+	return first, err
+}
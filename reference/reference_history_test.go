@@ -284,3 +284,65 @@ func TestFetchCommitByIDWithAWrongCommitID(t *testing.T) {
 
 	assert.Error(t, err, "Must return an error")
 }
+
+func TestFetchCurrentBranch(t *testing.T) {
+	branch, err := FetchCurrentBranch(repo)
+
+	assert.NoError(t, err, "Must return no errors")
+	assert.Equal(t, "test", branch, "Must return branch HEAD points to")
+
+	cmd := exec.Command("git", "checkout", "--quiet", "--detach", "HEAD")
+	cmd.Dir = gitRepositoryPath
+	assert.NoError(t, cmd.Run())
+
+	branch, err = FetchCurrentBranch(repo)
+
+	assert.NoError(t, err, "Must return no errors")
+	assert.Equal(t, "", branch, "Must return an empty branch when HEAD is detached")
+
+	cmd = exec.Command("git", "checkout", "--quiet", "test")
+	cmd.Dir = gitRepositoryPath
+	assert.NoError(t, cmd.Run())
+}
+
+func TestFetchCurrentBranchWithoutCommits(t *testing.T) {
+	emptyRepo, err := git.PlainInitWithOptions(t.TempDir(), &git.PlainInitOptions{
+		InitOptions: git.InitOptions{DefaultBranch: plumbing.NewBranchReferenceName("feature/PROJ-1")},
+	})
+	assert.NoError(t, err)
+
+	branch, err := FetchCurrentBranch(emptyRepo)
+
+	assert.NoError(t, err, "Must return no errors")
+	assert.Equal(t, "feature/PROJ-1", branch, "Must return branch HEAD points to before the first commit")
+}
+
+func TestCommitExists(t *testing.T) {
+	ID := getCommitFromRef("HEAD~1").ID().String()
+
+	type scenario struct {
+		ID     string
+		exists bool
+	}
+
+	scenarios := []scenario{
+		{ID, true},
+		{ID[:7], true},
+		{"0000000000000000000000000000000000000000", false},
+		{"abcdef1", false},
+	}
+
+	for _, s := range scenarios {
+		exists, err := CommitExists(repo, s.ID)
+
+		assert.NoError(t, err, "Must return no errors")
+		assert.Equal(t, s.exists, exists, "Must tell if commit %s exists", s.ID)
+	}
+}
+
+func TestFetchFirstCommitDate(t *testing.T) {
+	date, err := FetchFirstCommitDate(repo)
+
+	assert.NoError(t, err, "Must return no errors")
+	assert.True(t, date.Equal(getCommitFromRef("test~4").Author.When), "Must return author date of the first commit")
+}